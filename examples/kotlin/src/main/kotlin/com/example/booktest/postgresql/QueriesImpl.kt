@@ -26,7 +26,7 @@ WHERE tags && ?::varchar[]
 data class BooksByTagsRow (
   val bookId: Int,
   val title: String,
-  val name: String,
+  val name: String?,
   val isbn: String,
   val tags: List<String>
 )
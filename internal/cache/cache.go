@@ -0,0 +1,321 @@
+// Package cache stores compiled packages on disk so that packages whose
+// inputs haven't changed can skip parsing. Entries are content addressed: the
+// key is a hash of everything that can change the result, so stale entries
+// are never read and the cache never needs to be invalidated. Entries which
+// haven't been used for a few days are removed by Trim.
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
+)
+
+// Bump when the layout of cached results changes
+const format = "sqlc cache v1"
+
+func init() {
+	gob.Register(&catalog.Enum{})
+	gob.Register(&catalog.CompositeType{})
+}
+
+// Dir returns the cache directory: $SQLCCACHE if set, otherwise sqlc in the
+// user's cache directory ($XDG_CACHE_HOME or ~/.cache on Linux). It returns
+// an empty string if SQLCCACHE is set to off or no directory is available.
+func Dir() string {
+	dir := os.Getenv("SQLCCACHE")
+	if dir == "off" {
+		return ""
+	}
+	if dir != "" {
+		return dir
+	}
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(base, "sqlc")
+}
+
+// A Key identifies a compiled package
+type Key [sha256.Size]byte
+
+func (k Key) String() string {
+	return hex.EncodeToString(k[:])
+}
+
+// A Hash builds a Key from the inputs of a package
+type Hash struct {
+	h   hashWriter
+	err error
+}
+
+type hashWriter interface {
+	io.Writer
+	Sum([]byte) []byte
+}
+
+// NewHash starts a key for a build of sqlc identified by version. Builds
+// without a version, such as development builds, are identified by the hash
+// of their executable.
+func NewHash(version string) *Hash {
+	h := &Hash{h: sha256.New()}
+	h.Add("format", []byte(format))
+	h.Add("version", []byte(version))
+	if version == "" {
+		exe, err := executableHash()
+		if err != nil {
+			h.err = err
+		}
+		h.Add("executable", exe)
+	}
+	return h
+}
+
+// Add a named input
+func (h *Hash) Add(name string, contents []byte) {
+	fmt.Fprintf(h.h, "%s %d\n", name, len(contents))
+	h.h.Write(contents)
+}
+
+// AddFile adds the contents of a file
+func (h *Hash) AddFile(path string) {
+	blob, err := ioutil.ReadFile(path)
+	if err != nil && h.err == nil {
+		h.err = err
+	}
+	h.Add("file "+path, blob)
+}
+
+// Sum returns the key, or an error if an input couldn't be read
+func (h *Hash) Sum() (Key, error) {
+	var k Key
+	copy(k[:], h.h.Sum(nil))
+	return k, h.err
+}
+
+var exe struct {
+	once sync.Once
+	sum  []byte
+	err  error
+}
+
+func executableHash() ([]byte, error) {
+	exe.once.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			exe.err = err
+			return
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			exe.err = err
+			return
+		}
+		defer f.Close()
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			exe.err = err
+			return
+		}
+		exe.sum = h.Sum(nil)
+	})
+	return exe.sum, exe.err
+}
+
+func path(dir string, k Key) string {
+	s := k.String()
+	return filepath.Join(dir, s[:2], s+"-result")
+}
+
+const (
+	// Entries which haven't been used for this long are removed by Trim
+	trimAge = 5 * 24 * time.Hour
+	// How often Trim looks for unused entries
+	trimInterval = 24 * time.Hour
+	// How often the modification time of a used entry is updated, to avoid
+	// writing to the cache on every hit
+	usedInterval = time.Hour
+)
+
+// Load returns the result stored under k, if there is one
+func Load(dir string, k Key) (*compiler.Result, bool) {
+	p := path(dir, k)
+	blob, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, false
+	}
+	markUsed(p, time.Now())
+	var r compiler.Result
+	if err := gob.NewDecoder(bytes.NewReader(blob)).Decode(&r); err != nil {
+		return nil, false
+	}
+	if r.Catalog == nil {
+		return nil, false
+	}
+	r.Catalog.Extensions = map[string]struct{}{}
+	return &r, true
+}
+
+// Store saves the result under k. The file is written atomically so that
+// concurrent runs never read a partial entry.
+func Store(dir string, k Key, r *compiler.Result) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(trimResult(r)); err != nil {
+		return err
+	}
+	dest := path(dir, k)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(dest), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), dest)
+}
+
+// The modification time of an entry is the last time it was used
+func markUsed(p string, now time.Time) {
+	info, err := os.Stat(p)
+	if err != nil || now.Sub(info.ModTime()) < usedInterval {
+		return
+	}
+	os.Chtimes(p, now, now)
+}
+
+// Trim removes the entries which haven't been used recently. The cache is only
+// checked once a day; the time of the last check is kept in trim.txt.
+func Trim(dir string) error {
+	return trim(dir, time.Now())
+}
+
+func trim(dir string, now time.Time) error {
+	stamp := filepath.Join(dir, "trim.txt")
+	if blob, err := ioutil.ReadFile(stamp); err == nil {
+		if last, err := strconv.ParseInt(strings.TrimSpace(string(blob)), 10, 64); err == nil {
+			if now.Sub(time.Unix(last, 0)) < trimInterval {
+				return nil
+			}
+		}
+	}
+	subdirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	cutoff := now.Add(-trimAge)
+	for _, sub := range subdirs {
+		if !sub.IsDir() || len(sub.Name()) != 2 {
+			continue
+		}
+		entries, err := ioutil.ReadDir(filepath.Join(dir, sub.Name()))
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasSuffix(name, "-result") && !strings.HasPrefix(name, ".tmp-") {
+				continue
+			}
+			if entry.ModTime().Before(cutoff) {
+				os.Remove(filepath.Join(dir, sub.Name(), name))
+			}
+		}
+	}
+	return ioutil.WriteFile(stamp, []byte(strconv.FormatInt(now.Unix(), 10)+"\n"), 0644)
+}
+
+// The code generators only need the names of types, not the syntax trees
+// they were parsed from, so copy the result without them
+func trimResult(r *compiler.Result) *compiler.Result {
+	out := &compiler.Result{Catalog: trimCatalog(r.Catalog)}
+	for _, q := range r.Queries {
+		tq := *q
+		tq.Columns = make([]*compiler.Column, len(q.Columns))
+		for i, c := range q.Columns {
+			tq.Columns[i] = trimColumn(c)
+		}
+		tq.Params = make([]compiler.Parameter, len(q.Params))
+		for i, p := range q.Params {
+			tq.Params[i] = compiler.Parameter{Number: p.Number, Column: trimColumn(p.Column)}
+		}
+		out.Queries = append(out.Queries, &tq)
+	}
+	return out
+}
+
+func trimCatalog(c *catalog.Catalog) *catalog.Catalog {
+	out := &catalog.Catalog{
+		Comment:       c.Comment,
+		DefaultSchema: c.DefaultSchema,
+		Name:          c.Name,
+		SearchPath:    c.SearchPath,
+	}
+	for _, s := range c.Schemas {
+		ts := &catalog.Schema{Name: s.Name, Types: s.Types, Comment: s.Comment}
+		for _, t := range s.Tables {
+			tt := &catalog.Table{Rel: t.Rel, Comment: t.Comment}
+			for _, col := range t.Columns {
+				tc := *col
+				tc.Type = *trimType(&col.Type)
+				tt.Columns = append(tt.Columns, &tc)
+			}
+			ts.Tables = append(ts.Tables, tt)
+		}
+		for _, f := range s.Funcs {
+			tf := *f
+			tf.ReturnType = trimType(f.ReturnType)
+			tf.Args = make([]*catalog.Argument, len(f.Args))
+			for i, a := range f.Args {
+				ta := *a
+				ta.Type = trimType(a.Type)
+				tf.Args[i] = &ta
+			}
+			ts.Funcs = append(ts.Funcs, &tf)
+		}
+		out.Schemas = append(out.Schemas, ts)
+	}
+	return out
+}
+
+func trimColumn(c *compiler.Column) *compiler.Column {
+	if c == nil {
+		return nil
+	}
+	tc := *c
+	tc.Type = trimType(c.Type)
+	return &tc
+}
+
+func trimType(t *ast.TypeName) *ast.TypeName {
+	if t == nil {
+		return nil
+	}
+	return &ast.TypeName{Catalog: t.Catalog, Schema: t.Schema, Name: t.Name}
+}
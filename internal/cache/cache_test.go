@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrim(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	write := func(name string, age time.Duration) string {
+		p := filepath.Join(dir, "ab", name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(-age)
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	exists := func(p string) bool {
+		_, err := os.Stat(p)
+		return err == nil
+	}
+	recent := write("ab01-result", time.Hour)
+	unused := write("ab02-result", trimAge+time.Hour)
+	tmp := write(".tmp-123", trimAge+time.Hour)
+
+	if err := trim(dir, now); err != nil {
+		t.Fatal(err)
+	}
+	if !exists(recent) {
+		t.Errorf("recently used entry was removed")
+	}
+	if exists(unused) || exists(tmp) {
+		t.Errorf("unused entries were not removed")
+	}
+
+	// The cache is only trimmed once per interval
+	unused = write("ab03-result", trimAge+time.Hour)
+	if err := trim(dir, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if !exists(unused) {
+		t.Errorf("cache was trimmed twice within the interval")
+	}
+	if err := trim(dir, now.Add(trimInterval+time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if exists(unused) {
+		t.Errorf("unused entry was not removed after the interval")
+	}
+}
+
+func TestMarkUsed(t *testing.T) {
+	f, err := ioutil.TempFile("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	old := time.Now().Add(-2 * usedInterval).Truncate(time.Second)
+	if err := os.Chtimes(f.Name(), old, old); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().Truncate(time.Second)
+	markUsed(f.Name(), now)
+	info, err := os.Stat(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(now) {
+		t.Errorf("expected modification time %s, got %s", now, info.ModTime())
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/kyleconroy/sqlc/internal/cache"
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlpath"
+)
+
+// Unused cache entries are removed at most once per run
+var trimCache sync.Once
+
+// Like parse, but reuse the result of an earlier run with the same inputs.
+// The cache is best effort: if it can't be read or written, the package is
+// compiled as usual.
+func parseCached(e Env, t target, conf *config.Config, r *reporter) (*compiler.Result, bool) {
+	dir := cache.Dir()
+	if dir == "" || t.parseOpts.Debug.DumpAST || t.parseOpts.Debug.DumpCatalog {
+		return parse(e, t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, r)
+	}
+	trimCache.Do(func() {
+		cache.Trim(dir)
+	})
+	key, err := cacheKey(t, conf)
+	if err != nil {
+		return parse(e, t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, r)
+	}
+	if result, ok := cache.Load(dir, key); ok {
+		return result, false
+	}
+	result, failed := parse(e, t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, r)
+	if !failed {
+		cache.Store(dir, key, result)
+	}
+	return result, failed
+}
+
+// The key covers the sqlc build, the whole configuration, the target and the
+// contents of every schema and query file the compiler would read
+func cacheKey(t target, conf *config.Config) (cache.Key, error) {
+	h := cache.NewHash(version)
+	blob, err := json.Marshal(conf)
+	if err != nil {
+		return cache.Key{}, err
+	}
+	h.Add("config", blob)
+	blob, err = json.Marshal(t.sql)
+	if err != nil {
+		return cache.Key{}, err
+	}
+	h.Add("target", blob)
+	h.Add("parser", []byte(fmt.Sprintf("%+v", t.parseOpts)))
+	for _, paths := range [][]string{t.sql.Schema, t.sql.Queries} {
+		files, err := sqlpath.Glob(paths)
+		if err != nil {
+			return cache.Key{}, err
+		}
+		for _, f := range files {
+			h.AddFile(f)
+		}
+	}
+	return h.Sum()
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cacheDir := filepath.Join(dir, "cache")
+	os.Setenv("SQLCCACHE", cacheDir)
+	defer os.Setenv("SQLCCACHE", "off")
+
+	for name, contents := range map[string]string{
+		"sqlc.json":  `{"version": "1", "packages": [{"path": "db", "schema": "schema.sql", "queries": "query.sql"}]}`,
+		"schema.sql": `CREATE TYPE mood AS ENUM ('happy', 'sad'); CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL, mood mood);`,
+		"query.sql": `-- name: ListAuthors :many
+SELECT * FROM authors WHERE mood = $1;
+`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := func() int {
+		matches, _ := filepath.Glob(filepath.Join(cacheDir, "*", "*-result"))
+		return len(matches)
+	}
+
+	var stderr bytes.Buffer
+	cold, err := Generate(Env{}, dir, "", &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	if n := entries(); n != 1 {
+		t.Fatalf("expected one cache entry, found %d", n)
+	}
+	warm, err := Generate(Env{}, dir, "", &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	if diff := cmp.Diff(cold, warm); diff != "" {
+		t.Errorf("cached output differed (-want +got):\n%s", diff)
+	}
+	if n := entries(); n != 1 {
+		t.Fatalf("expected one cache entry, found %d", n)
+	}
+
+	// Changing an input results in a new entry
+	if err := ioutil.WriteFile(filepath.Join(dir, "query.sql"), []byte("-- name: ListAuthors :many\nSELECT id FROM authors;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Generate(Env{}, dir, "", &stderr); err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	if n := entries(); n != 2 {
+		t.Fatalf("expected two cache entries, found %d", n)
+	}
+}
@@ -17,10 +17,24 @@ import (
 // Do runs the command logic.
 func Do(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
 	rootCmd := &cobra.Command{Use: "sqlc", SilenceUsage: true}
+	rootCmd.PersistentFlags().StringP("file", "f", "", "specify an alternate config file (default: sqlc.yaml or sqlc.json in the nearest parent directory)")
 	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(genCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(lspCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(vetCmd)
+
+	for _, c := range []*cobra.Command{checkCmd, genCmd, vetCmd} {
+		c.Flags().String("format", "text", "format of reported errors: text, json or sarif")
+	}
+	describeCmd.Flags().StringP("output", "o", "table", "output format: table or json")
+	for _, c := range []*cobra.Command{checkCmd, genCmd} {
+		c.Flags().IntP("jobs", "j", 0, "number of packages to build in parallel, defaults to the number of CPUs")
+	}
+	genCmd.Flags().Bool("watch", false, "regenerate code whenever the configuration, schema or query files change")
 
 	rootCmd.SetArgs(args)
 	rootCmd.SetIn(stdin)
@@ -57,22 +71,32 @@ var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Create an empty sqlc.yaml settings file",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if _, err := os.Stat("sqlc.yaml"); !os.IsNotExist(err) {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			file = "sqlc.yaml"
+		}
+		if _, err := os.Stat(file); !os.IsNotExist(err) {
 			return nil
 		}
 		blob, err := yaml.Marshal(config.V1GenerateSettings{Version: "1"})
 		if err != nil {
 			return err
 		}
-		return ioutil.WriteFile("sqlc.yaml", blob, 0644)
+		return ioutil.WriteFile(file, blob, 0644)
 	},
 }
 
 type Env struct {
+	// The format used to report errors: text, json or sarif
+	Format string
+	// The number of packages to build at once. Zero means one per CPU.
+	Jobs int
 }
 
-func ParseEnv() Env {
-	return Env{}
+func ParseEnv(c *cobra.Command) Env {
+	format, _ := c.Flags().GetString("format")
+	jobs, _ := c.Flags().GetInt("jobs")
+	return Env{Format: format, Jobs: jobs}
 }
 
 var genCmd = &cobra.Command{
@@ -85,8 +109,16 @@ var genCmd = &cobra.Command{
 			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
 			os.Exit(1)
 		}
+		file, _ := cmd.Flags().GetString("file")
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			if err := Watch(ParseEnv(cmd), dir, file, cmd.OutOrStdout(), stderr); err != nil {
+				os.Exit(1)
+			}
+			return
+		}
 
-		output, err := Generate(ParseEnv(), dir, stderr)
+		output, err := Generate(ParseEnv(cmd), dir, file, stderr)
 		if err != nil {
 			os.Exit(1)
 		}
@@ -111,7 +143,87 @@ var checkCmd = &cobra.Command{
 			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
 			os.Exit(1)
 		}
-		if _, err := Generate(Env{}, dir, stderr); err != nil {
+		file, _ := cmd.Flags().GetString("file")
+		if _, err := Generate(ParseEnv(cmd), dir, file, stderr); err != nil {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe package [query]",
+	Short: "Print the parameters and columns inferred for each query",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			os.Exit(1)
+		}
+		file, _ := cmd.Flags().GetString("file")
+		output, _ := cmd.Flags().GetString("output")
+		var query string
+		if len(args) > 1 {
+			query = args[1]
+		}
+		if err := Describe(ParseEnv(cmd), dir, file, args[0], query, output, cmd.OutOrStdout(), stderr); err != nil {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare the generated files to the existing files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			os.Exit(1)
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if err := Diff(ParseEnv(cmd), dir, file, cmd.OutOrStdout(), stderr); err != nil {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run a language server on stdin and stdout",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			os.Exit(1)
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if err := LSP(ParseEnv(cmd), dir, file, cmd.InOrStdin(), cmd.OutOrStdout(), stderr); err != nil {
+			fmt.Fprintf(stderr, "error running language server: %s\n", err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+var vetCmd = &cobra.Command{
+	Use:   "vet",
+	Short: "Check queries for common mistakes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			os.Exit(1)
+		}
+		file, _ := cmd.Flags().GetString("file")
+		if err := Vet(ParseEnv(cmd), dir, file, stderr); err != nil {
 			os.Exit(1)
 		}
 		return nil
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kyleconroy/sqlc/internal/codegen/golang"
+	"github.com/kyleconroy/sqlc/internal/codegen/kotlin"
+	"github.com/kyleconroy/sqlc/internal/compiler"
+)
+
+// A describedQuery is what the compiler inferred about a single query
+type describedQuery struct {
+	Package  string            `json:"package"`
+	Name     string            `json:"name"`
+	Cmd      string            `json:"cmd"`
+	Filename string            `json:"filename"`
+	Params   []describedColumn `json:"params"`
+	Columns  []describedColumn `json:"columns"`
+	SQL      string            `json:"sql"`
+}
+
+type describedColumn struct {
+	// Only set for parameters
+	Number  int    `json:"number,omitempty"`
+	Name    string `json:"name"`
+	Table   string `json:"table,omitempty"`
+	DBType  string `json:"db_type"`
+	NotNull bool   `json:"not_null"`
+	IsArray bool   `json:"is_array"`
+	// The type used in generated code
+	Type string `json:"type"`
+}
+
+// Describe prints the parameters, output columns, command and rewritten SQL
+// of every query in package pkg, or only of the query named query if it isn't
+// empty. The package is either a package name or an output directory. The
+// output format is either table or json.
+func Describe(e Env, dir, filename, pkg, query, output string, stdout, stderr io.Writer) error {
+	if output != "table" && output != "json" {
+		fmt.Fprintf(stderr, "error parsing --output: unknown format %q\n", output)
+		return fmt.Errorf("unknown format %q", output)
+	}
+	r, err := newReporter(e.Format, dir, stderr)
+	if err != nil {
+		return err
+	}
+	described, err := describe(e, dir, filename, pkg, query, r)
+	if err := r.flush(); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if output == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(described)
+	}
+	return writeDescribeTable(stdout, described)
+}
+
+func describe(e Env, dir, filename, pkg, name string, r *reporter) ([]describedQuery, error) {
+	configPath, conf, err := readConfig(r, dir, filename)
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Dir(configPath)
+
+	debug, err := debugFromEnv(r)
+	if err != nil {
+		return nil, err
+	}
+
+	described := []describedQuery{}
+	var found bool
+	for _, pair := range outPairs(conf) {
+		if pair.Gen.Go == nil && pair.Gen.Kotlin == nil {
+			continue
+		}
+		t := newTarget(dir, conf, pair, debug)
+		if t.name != pkg && filepath.Clean(t.out()) != filepath.Clean(pkg) {
+			continue
+		}
+		found = true
+		result, failed := parse(e, t.name, dir, t.sql.SQL, t.combo, t.parseOpts, r)
+		if failed {
+			return nil, errors.New("errored")
+		}
+		for _, q := range result.Queries {
+			if q.Name == "" || (name != "" && q.Name != name) {
+				continue
+			}
+			described = append(described, t.describeQuery(result, q))
+		}
+	}
+	if !found {
+		err := fmt.Errorf("package %q not found", pkg)
+		r.configErr(configPath, fmt.Sprintf("error describing queries: %s\n", err), err)
+		return nil, err
+	}
+	if name != "" && len(described) == 0 {
+		err := fmt.Errorf("query %q not found in package %q", name, pkg)
+		r.configErr(configPath, fmt.Sprintf("error describing queries: %s\n", err), err)
+		return nil, err
+	}
+	return described, nil
+}
+
+func (t target) describeQuery(result *compiler.Result, q *compiler.Query) describedQuery {
+	d := describedQuery{
+		Package:  t.name,
+		Name:     q.Name,
+		Cmd:      q.Cmd,
+		Filename: q.Filename,
+		Params:   []describedColumn{},
+		Columns:  []describedColumn{},
+		SQL:      q.SQL,
+	}
+	for _, p := range q.Params {
+		name := p.Column.Name
+		if name == "" {
+			name = fmt.Sprintf("dollar_%d", p.Number)
+		}
+		col := t.describeColumn(result, q.Name, name, "", p.Column)
+		col.Number = p.Number
+		d.Params = append(d.Params, col)
+	}
+	for i, c := range q.Columns {
+		name := c.Name
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		d.Columns = append(d.Columns, t.describeColumn(result, q.Name, "", name, c))
+	}
+	return d
+}
+
+// The name of a parameter or output column is the one per-query overrides
+// use, so that the type matches the generated code
+func (t target) describeColumn(result *compiler.Result, query, param, column string, c *compiler.Column) describedColumn {
+	name := param
+	if name == "" {
+		name = column
+	}
+	col := describedColumn{
+		Name:    name,
+		DBType:  c.DataType,
+		NotNull: c.NotNull,
+		IsArray: c.IsArray,
+	}
+	if c.Table != nil {
+		col.Table = c.Table.Name
+	}
+	switch {
+	case t.sql.Gen.Go != nil:
+		col.Type = golang.QueryGoType(result, query, param, column, c, t.combo)
+	case t.sql.Gen.Kotlin != nil:
+		col.Type = kotlin.KotlinType(result, c, t.combo)
+	}
+	return col
+}
+
+func writeDescribeTable(w io.Writer, queries []describedQuery) error {
+	for i, q := range queries {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", q.Name, q.Cmd, q.Filename)
+		fmt.Fprintln(w, "\nSQL:")
+		for _, line := range strings.Split(q.SQL, "\n") {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+		if len(q.Params) > 0 {
+			fmt.Fprintln(w, "\nParameters:")
+			tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "  #\tNAME\tTABLE\tDB TYPE\tNULL\tTYPE")
+			for _, p := range q.Params {
+				fmt.Fprintf(tw, "  $%d\t%s\t%s\t%s\t%s\t%s\n", p.Number, p.Name, p.Table, dbType(p), nullable(p), p.Type)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		if len(q.Columns) > 0 {
+			fmt.Fprintln(w, "\nColumns:")
+			tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(tw, "  NAME\tTABLE\tDB TYPE\tNULL\tTYPE")
+			for _, c := range q.Columns {
+				fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", c.Name, c.Table, dbType(c), nullable(c), c.Type)
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func dbType(c describedColumn) string {
+	if c.IsArray {
+		return c.DBType + "[]"
+	}
+	return c.DBType
+}
+
+func nullable(c describedColumn) string {
+	if c.NotNull {
+		return "NOT NULL"
+	}
+	return "NULL"
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDescribe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-describe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.json": `{"version": "1", "packages": [{"path": "db", "schema": "schema.sql", "queries": "query.sql", "overrides": [
+  {"query": "UpdateBio", "param": "id", "go_type": "int32"},
+  {"query": "ListAuthors", "column": "name", "go_type": "github.com/example/names.Name"}
+]}]}`,
+		"schema.sql": `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);`,
+		"query.sql": `-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: UpdateBio :exec
+UPDATE authors SET bio = sqlc.arg(new_bio) WHERE id = sqlc.arg(id);
+`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Describe(Env{}, dir, "", "db", "UpdateBio", "table", &stdout, &stderr); err != nil {
+		t.Fatalf("describe failed: %s", stderr.String())
+	}
+	expected := `UpdateBio :exec (query.sql)
+
+SQL:
+  UPDATE authors SET bio = $1 WHERE id = $2
+
+Parameters:
+  #   NAME     TABLE    DB TYPE    NULL      TYPE
+  $1  new_bio  authors  text       NULL      sql.NullString
+  $2  id       authors  bigserial  NOT NULL  int32
+`
+	if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+
+	stdout.Reset()
+	if err := Describe(Env{}, dir, "", "db", "ListAuthors", "table", &stdout, &stderr); err != nil {
+		t.Fatalf("describe failed: %s", stderr.String())
+	}
+	expected = `ListAuthors :many (query.sql)
+
+SQL:
+  SELECT id, name, bio FROM authors
+
+Columns:
+  NAME  TABLE    DB TYPE    NULL      TYPE
+  id    authors  bigserial  NOT NULL  int64
+  name  authors  text       NOT NULL  names.Name
+  bio   authors  text       NULL      sql.NullString
+`
+	if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+
+	if err := Describe(Env{}, dir, "", "db", "Missing", "table", &stdout, &stderr); err == nil {
+		t.Errorf("expected error for a missing query")
+	}
+}
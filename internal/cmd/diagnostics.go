@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/multierr"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
+)
+
+const (
+	formatText  = "text"
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+// A Diagnostic describes a single problem found while processing the
+// configuration file or a package
+type Diagnostic struct {
+	Package   string `json:"package,omitempty"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	EndColumn int    `json:"end_column,omitempty"`
+	Severity  string `json:"severity"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+}
+
+// A reporter writes errors to stderr. The text format prints each error as
+// soon as it's reported. The json and sarif formats collect every error and
+// write a single document when flush is called, even if there are no errors.
+type reporter struct {
+	format string
+	// File paths in diagnostics are made relative to this directory
+	wd     string
+	stderr io.Writer
+	diags  []Diagnostic
+	// Set for reporters created by buffered
+	buf *bytes.Buffer
+}
+
+func newReporter(format, wd string, stderr io.Writer) (*reporter, error) {
+	switch format {
+	case "":
+		format = formatText
+	case formatText, formatJSON, formatSARIF:
+	default:
+		fmt.Fprintf(stderr, "error parsing --format: unknown format %q\n", format)
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+	return &reporter{format: format, wd: wd, stderr: stderr}, nil
+}
+
+// A buffered reporter holds on to the errors of a package built concurrently
+// with others until they're merged back, so that errors are printed in a
+// deterministic order and never interleaved
+func (r *reporter) buffered() *reporter {
+	buf := &bytes.Buffer{}
+	return &reporter{format: r.format, wd: r.wd, stderr: buf, buf: buf}
+}
+
+func (r *reporter) merge(b *reporter) {
+	r.stderr.Write(b.buf.Bytes())
+	r.diags = append(r.diags, b.diags...)
+}
+
+// Report an error that doesn't belong to a package, such as a problem with
+// the configuration file. The text format prints text unchanged.
+func (r *reporter) configErr(file, text string, err error) {
+	if r.format == formatText {
+		fmt.Fprint(r.stderr, text)
+		return
+	}
+	d := Diagnostic{Severity: "error", Message: err.Error()}
+	if file != "" {
+		d.File = r.rel(file)
+	}
+	r.diags = append(r.diags, d)
+}
+
+// Report an error found while processing package pkg. The position of each
+// error returned by the compiler is kept; any other error is reported with
+// prefix, such as "error parsing schema".
+func (r *reporter) packageErr(pkg, dir, prefix string, err error) {
+	if r.format == formatText {
+		fmt.Fprintf(r.stderr, "# package %s\n", pkg)
+		if parserErr, ok := err.(*multierr.Error); ok {
+			for _, fileErr := range parserErr.Errs() {
+				printFileErr(r.stderr, dir, fileErr)
+			}
+		} else {
+			fmt.Fprintf(r.stderr, "%s: %s\n", prefix, err)
+		}
+		return
+	}
+
+	parserErr, ok := err.(*multierr.Error)
+	if !ok {
+		r.diags = append(r.diags, Diagnostic{
+			Package:  pkg,
+			Severity: "error",
+			Code:     errCode(err),
+			Message:  fmt.Sprintf("%s: %s", prefix, err),
+		})
+		return
+	}
+	for _, fileErr := range parserErr.Errs() {
+		r.diags = append(r.diags, Diagnostic{
+			Package:   pkg,
+			File:      r.rel(fileErr.Filename),
+			Line:      fileErr.Line,
+			Column:    fileErr.Column,
+			EndLine:   fileErr.EndLine,
+			EndColumn: fileErr.EndColumn,
+			Severity:  severity(fileErr.Err),
+			Code:      errCode(fileErr.Err),
+			Message:   fileErr.Err.Error(),
+		})
+	}
+}
+
+// Problems found by vet rules are warnings, so that they can be told apart
+// from queries that don't compile. Both fail the command.
+func severity(err error) string {
+	if compiler.IsViolation(err) {
+		return "warning"
+	}
+	return "error"
+}
+
+func errCode(err error) string {
+	var serr *sqlerr.Error
+	if errors.As(err, &serr) {
+		return serr.Code
+	}
+	return ""
+}
+
+func (r *reporter) rel(file string) string {
+	if rel, err := filepath.Rel(r.wd, file); err == nil {
+		return rel
+	}
+	return file
+}
+
+// Write the collected diagnostics
+func (r *reporter) flush() error {
+	var doc interface{}
+	switch r.format {
+	case formatJSON:
+		diags := r.diags
+		if diags == nil {
+			diags = []Diagnostic{}
+		}
+		doc = struct {
+			Diagnostics []Diagnostic `json:"diagnostics"`
+		}{diags}
+	case formatSARIF:
+		doc = sarifLog(r.diags)
+	default:
+		return nil
+	}
+	r.diags = nil
+	enc := json.NewEncoder(r.stderr)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// The subset of the Static Analysis Results Interchange Format (SARIF) 2.1.0
+// needed to describe diagnostics
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId,omitempty"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+func sarifLog(diags []Diagnostic) sarifReport {
+	results := []sarifResult{}
+	for _, d := range diags {
+		res := sarifResult{
+			RuleID:  d.Code,
+			Level:   d.Severity,
+			Message: sarifMessage{Text: d.Message},
+		}
+		if d.Package != "" {
+			res.Properties = map[string]string{"package": d.Package}
+		}
+		if d.File != "" {
+			loc := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)},
+				},
+			}
+			if d.Line != 0 {
+				loc.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   d.Line,
+					StartColumn: d.Column,
+					EndLine:     d.EndLine,
+					EndColumn:   d.EndColumn,
+				}
+			}
+			res.Locations = []sarifLocation{loc}
+		}
+		results = append(results, res)
+	}
+	return sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "sqlc",
+						Version:        version,
+						InformationURI: "https://github.com/kyleconroy/sqlc",
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/multierr"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
+)
+
+func TestReporterJSON(t *testing.T) {
+	query := "-- name: GetAuthor :one\nSELECT nope FROM authors;\n"
+	merr := multierr.New()
+	merr.Add("/src/db/query.sql", query, 0, &sqlerr.Error{
+		Code:     "42703",
+		Message:  `column "nope" does not exist`,
+		Location: 31,
+	})
+
+	var stderr bytes.Buffer
+	r, err := newReporter(formatJSON, "/src", &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.packageErr("db", "/src/db", "error parsing queries", merr)
+	r.packageErr("db", "/src/db", "error generating code", errors.New("boom"))
+	if err := r.flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		Diagnostics []Diagnostic
+	}
+	if err := json.Unmarshal(stderr.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	expected := []Diagnostic{
+		{
+			Package:   "db",
+			File:      "db/query.sql",
+			Line:      2,
+			Column:    8,
+			EndLine:   2,
+			EndColumn: 12,
+			Severity:  "error",
+			Code:      "42703",
+			Message:   `column "nope" does not exist`,
+		},
+		{
+			Package:  "db",
+			Severity: "error",
+			Message:  "error generating code: boom",
+		},
+	}
+	if diff := cmp.Diff(expected, doc.Diagnostics); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestReporterVetWarning(t *testing.T) {
+	query := "-- name: DeleteAuthors :exec\nDELETE FROM authors;\n"
+	merr := multierr.New()
+	merr.Add("/src/db/query.sql", query, 0, &sqlerr.Error{
+		Code:     "missing-where",
+		Message:  "missing-where: DELETE without a WHERE clause removes every row",
+		Location: 29,
+	})
+	merr.Add("/src/db/query.sql", query, 0, &sqlerr.Error{
+		Code:     "42P01",
+		Message:  `relation "authors" does not exist`,
+		Location: 41,
+	})
+
+	r, err := newReporter(formatSARIF, "/src", &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.packageErr("db", "/src/db", "error parsing queries", merr)
+	var severities, levels []string
+	for _, d := range r.diags {
+		severities = append(severities, d.Severity)
+	}
+	for _, res := range sarifLog(r.diags).Runs[0].Results {
+		levels = append(levels, res.Level)
+	}
+	expected := []string{"warning", "error"}
+	if diff := cmp.Diff(expected, severities); diff != "" {
+		t.Errorf("severity differed (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(expected, levels); diff != "" {
+		t.Errorf("level differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	var stderr bytes.Buffer
+	if _, err := newReporter("xml", "", &stderr); err == nil {
+		t.Fatal("expected error; got nil")
+	}
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const generatedHeader = "// Code generated by sqlc. DO NOT EDIT."
+
+const diffContext = 3
+
+// Diff compares the output of Generate against the files on disk and writes a
+// unified diff of every difference to stdout. Generated files which exist on
+// disk but are no longer produced are reported as deletions. An error is
+// returned if any difference is found.
+func Diff(e Env, dir, filename string, stdout, stderr io.Writer) error {
+	output, err := Generate(e, dir, filename, stderr)
+	if err != nil {
+		return err
+	}
+
+	dirs := map[string]struct{}{}
+	filenames := make([]string, 0, len(output))
+	for filename := range output {
+		filenames = append(filenames, filename)
+		dirs[filepath.Dir(filename)] = struct{}{}
+	}
+
+	// Find files sqlc generated in a previous run that are no longer part of
+	// the output
+	for d := range dirs {
+		entries, err := ioutil.ReadDir(d)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			fmt.Fprintf(stderr, "%s: %s\n", d, err)
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			filename := filepath.Join(d, entry.Name())
+			if _, ok := output[filename]; ok {
+				continue
+			}
+			blob, err := ioutil.ReadFile(filename)
+			if err != nil {
+				fmt.Fprintf(stderr, "%s: %s\n", filename, err)
+				return err
+			}
+			if strings.HasPrefix(string(blob), generatedHeader) {
+				filenames = append(filenames, filename)
+			}
+		}
+	}
+	sort.Strings(filenames)
+
+	var differs bool
+	for _, filename := range filenames {
+		var existing string
+		blob, readErr := ioutil.ReadFile(filename)
+		switch {
+		case readErr == nil:
+			existing = string(blob)
+		case os.IsNotExist(readErr):
+		default:
+			fmt.Fprintf(stderr, "%s: %s\n", filename, readErr)
+			return readErr
+		}
+
+		generated, produced := output[filename]
+		if existing == generated {
+			continue
+		}
+		differs = true
+
+		name, err := filepath.Rel(dir, filename)
+		if err != nil {
+			name = filename
+		}
+		from, to := "a/"+name, "b/"+name
+		if os.IsNotExist(readErr) {
+			from = "/dev/null"
+		}
+		if !produced {
+			to = "/dev/null"
+		}
+		fmt.Fprintf(stdout, "--- %s\n", from)
+		fmt.Fprintf(stdout, "+++ %s\n", to)
+		writeHunks(stdout, diffLines(splitLines(existing), splitLines(generated)))
+	}
+
+	if differs {
+		return errors.New("generated code differs")
+	}
+	return nil
+}
+
+const noNewline = "\n\\ No newline at end of file"
+
+// splitLines splits s into lines. A last line without a trailing newline
+// carries the marker diff prints after it, so that it differs from the same
+// line with a newline.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if !strings.HasSuffix(s, "\n") {
+		lines[len(lines)-1] += noNewline
+	}
+	return lines
+}
+
+type lineOp struct {
+	kind byte // One of ' ', '-', or '+'
+	text string
+}
+
+// diffLines computes the shortest edit script between a and b using Myers'
+// algorithm.
+//
+// http://www.xmailserver.org/diff2.pdf
+func diffLines(a, b []string) []lineOp {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// Only keep the diagonals reachable at each step to avoid storing
+	// quadratic amounts of state for large files
+	var trace [][]int
+	at := func(d, k int) int {
+		return trace[d][k+d+1]
+	}
+
+search:
+	for d := 0; d <= max; d++ {
+		snapshot := make([]int, 2*d+3)
+		copy(snapshot, v[offset-d-1:offset+d+2])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	var ops []lineOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(d, k-1) < at(d, k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(d, prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, lineOp{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, lineOp{'+', b[y-1]})
+			} else {
+				ops = append(ops, lineOp{'-', a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// writeHunks prints the edit script in unified diff format, keeping
+// diffContext lines of unchanged text around each change
+func writeHunks(w io.Writer, ops []lineOp) {
+	// Line numbers (zero-indexed) in each file before ops[i] is applied
+	aLine := make([]int, len(ops)+1)
+	bLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if op.kind != '+' {
+			aLine[i+1]++
+		}
+		if op.kind != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	i := 0
+	for i < len(ops) {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk until the gap between two changes is too large to
+		// be covered by context
+		end := i
+		for j := i; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j
+				continue
+			}
+			if j-end > 2*diffContext {
+				break
+			}
+		}
+		stop := end + diffContext + 1
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		fmt.Fprintf(w, "@@ -%s +%s @@\n",
+			hunkRange(aLine[start], aLine[stop]-aLine[start]),
+			hunkRange(bLine[start], bLine[stop]-bLine[start]),
+		)
+		for _, op := range ops[start:stop] {
+			fmt.Fprintf(w, "%c%s\n", op.kind, op.text)
+		}
+		i = stop
+	}
+}
+
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
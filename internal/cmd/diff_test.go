@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteHunks(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		a    string
+		b    string
+		diff string
+	}{
+		{
+			"identical",
+			"a\nb\nc\n",
+			"a\nb\nc\n",
+			"",
+		},
+		{
+			"new file",
+			"",
+			"a\nb\n",
+			"@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			"removed file",
+			"a\n",
+			"",
+			"@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			"change",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			"1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			"separate hunks",
+			"a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n",
+			"A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n",
+			"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n",
+		},
+		{
+			"missing newline",
+			"a\nb\n",
+			"a\nb",
+			"@@ -1,2 +1,2 @@\n a\n-b\n+b\n\\ No newline at end of file\n",
+		},
+	} {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeHunks(&buf, diffLines(splitLines(tt.a), splitLines(tt.b)))
+			if diff := cmp.Diff(tt.diff, buf.String()); diff != "" {
+				t.Errorf("differed (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -8,16 +8,20 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/kyleconroy/sqlc/internal/codegen/golang"
 	"github.com/kyleconroy/sqlc/internal/codegen/kotlin"
 	"github.com/kyleconroy/sqlc/internal/compiler"
 	"github.com/kyleconroy/sqlc/internal/config"
 	"github.com/kyleconroy/sqlc/internal/debug"
+	"github.com/kyleconroy/sqlc/internal/ir"
 	"github.com/kyleconroy/sqlc/internal/multierr"
 	"github.com/kyleconroy/sqlc/internal/mysql"
 	"github.com/kyleconroy/sqlc/internal/opts"
+	"github.com/kyleconroy/sqlc/internal/plugin"
 )
 
 const errMessageNoVersion = `The configuration file must have a version number.
@@ -45,62 +49,216 @@ type outPair struct {
 	config.SQL
 }
 
-func Generate(e Env, dir string, stderr io.Writer) (map[string]string, error) {
-	var yamlMissing, jsonMissing bool
-	yamlPath := filepath.Join(dir, "sqlc.yaml")
-	jsonPath := filepath.Join(dir, "sqlc.json")
-
-	if _, err := os.Stat(yamlPath); os.IsNotExist(err) {
-		yamlMissing = true
-	}
-	if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
-		jsonMissing = true
+// Find the configuration file. An explicit filename is resolved against dir.
+// Otherwise dir and then each of its parents are searched for a sqlc.yaml or
+// sqlc.json file, the same way the go command finds go.mod.
+func findConfig(r *reporter, dir, filename string) (string, error) {
+	if filename != "" {
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(dir, filename)
+		}
+		if _, err := os.Stat(filename); os.IsNotExist(err) {
+			err := errors.New("config file missing")
+			r.configErr(filename, fmt.Sprintf("error parsing %s: file does not exist\n", filepath.Base(filename)), err)
+			return "", err
+		}
+		return filename, nil
 	}
 
-	if yamlMissing && jsonMissing {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, errors.New("config file missing")
-	}
+	for {
+		var yamlMissing, jsonMissing bool
+		yamlPath := filepath.Join(dir, "sqlc.yaml")
+		jsonPath := filepath.Join(dir, "sqlc.json")
+
+		if _, err := os.Stat(yamlPath); os.IsNotExist(err) {
+			yamlMissing = true
+		}
+		if _, err := os.Stat(jsonPath); os.IsNotExist(err) {
+			jsonMissing = true
+		}
 
-	if !yamlMissing && !jsonMissing {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: both files present")
-		return nil, errors.New("sqlc.json and sqlc.yaml present")
+		if !yamlMissing && !jsonMissing {
+			err := errors.New("sqlc.json and sqlc.yaml present")
+			r.configErr(jsonPath, "error parsing sqlc.json: both files present\n", err)
+			return "", err
+		}
+		if !yamlMissing {
+			return yamlPath, nil
+		}
+		if !jsonMissing {
+			return jsonPath, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			err := errors.New("config file missing")
+			r.configErr("", "error parsing sqlc.json: file does not exist\n", err)
+			return "", err
+		}
+		dir = parent
 	}
+}
 
-	configPath := yamlPath
-	if yamlMissing {
-		configPath = jsonPath
+// Read the configuration file located by findConfig. Relative paths in the
+// configuration are resolved against the directory holding the file, which
+// is returned along with the file's path.
+func readConfig(r *reporter, dir, filename string) (string, *config.Config, error) {
+	configPath, err := findConfig(r, dir, filename)
+	if err != nil {
+		return "", nil, err
 	}
 
 	blob, err := ioutil.ReadFile(configPath)
 	if err != nil {
-		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, err
+		r.configErr(configPath, "error parsing sqlc.json: file does not exist\n", err)
+		return "", nil, err
 	}
 
-	conf, err := config.ParseConfig(bytes.NewReader(blob))
+	conf, err := config.ParseConfigFile(configPath, bytes.NewReader(blob))
 	if err != nil {
+		var text string
 		switch err {
 		case config.ErrMissingVersion:
-			fmt.Fprintf(stderr, errMessageNoVersion)
+			text = errMessageNoVersion
 		case config.ErrUnknownVersion:
-			fmt.Fprintf(stderr, errMessageUnknownVersion)
+			text = errMessageUnknownVersion
 		case config.ErrNoPackages:
-			fmt.Fprintf(stderr, errMessageNoPackages)
+			text = errMessageNoPackages
 		}
-		fmt.Fprintf(stderr, "error parsing sqlc.json: %s\n", err)
+		text += fmt.Sprintf("error parsing sqlc.json: %s\n", err)
+		r.configErr(configPath, text, err)
+		return "", nil, err
+	}
+
+	return configPath, &conf, nil
+}
+
+// Generate returns the generated files for the configuration found by
+// searching from dir, or for filename if it isn't empty. The returned files
+// are keyed by their absolute path.
+func Generate(e Env, dir, filename string, stderr io.Writer) (map[string]string, error) {
+	r, err := newReporter(e.Format, dir, stderr)
+	if err != nil {
 		return nil, err
 	}
+	output, err := generate(e, dir, filename, r)
+	if err := r.flush(); err != nil {
+		return nil, err
+	}
+	return output, err
+}
 
-	debug, err := opts.DebugFromEnv()
+func generate(e Env, dir, filename string, r *reporter) (map[string]string, error) {
+	configPath, conf, err := readConfig(r, dir, filename)
 	if err != nil {
-		fmt.Fprintf(stderr, "error parsing SQLCDEBUG: %s\n", err)
 		return nil, err
 	}
+	dir = filepath.Dir(configPath)
+
+	debug, err := debugFromEnv(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each target builds its own compiler and catalog, so targets are built
+	// concurrently. Their errors and files are merged in configuration order.
+	pairs := outPairs(conf)
+	type built struct {
+		r      *reporter
+		files  map[string]string
+		failed bool
+	}
+	builds := make([]built, len(pairs))
+	jobs := e.Jobs
+	if debug.DumpAST || debug.DumpCatalog {
+		// Keep the dumps of different packages apart
+		jobs = 1
+	}
+	parallel(jobs, len(pairs), func(i int) {
+		t := newTarget(dir, conf, pairs[i], debug)
+		b := built{r: r.buffered()}
+		b.files, b.failed = t.build(e, conf, b.r)
+		builds[i] = b
+	})
 
 	output := map[string]string{}
 	errored := false
+	for _, b := range builds {
+		r.merge(b.r)
+		if b.failed {
+			errored = true
+			continue
+		}
+		for n, source := range b.files {
+			output[n] = source
+		}
+	}
+
+	if errored {
+		return nil, fmt.Errorf("errored")
+	}
+	return output, nil
+}
+
+// Compile and generate code for a target, returning the generated files
+// keyed by their path on disk
+func (t target) build(e Env, conf *config.Config, r *reporter) (map[string]string, bool) {
+	var files map[string]string
+	var err error
+
+	// TODO: Note about how this will be going away
+	if t.sql.Engine == config.EngineMySQL {
+		result, failed := parseMySQL(e, t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, r)
+		if failed {
+			return nil, true
+		}
+		files, err = golang.DeprecatedGenerate(result, t.combo)
+	} else {
+		result, failed := parseCached(e, t, conf, r)
+		if failed {
+			return nil, true
+		}
+		files, err = t.codegen(result)
+	}
+
+	if err != nil {
+		r.packageErr(t.name, t.dir, "error generating code", err)
+		return nil, true
+	}
+	return t.outputPaths(files), false
+}
 
+// Call f for 0 through n-1, running at most jobs calls at once. Zero jobs
+// means one per CPU.
+func parallel(jobs, n int, f func(i int)) {
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	sem := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func debugFromEnv(r *reporter) (opts.Debug, error) {
+	debug, err := opts.DebugFromEnv()
+	if err != nil {
+		r.configErr("", fmt.Sprintf("error parsing SQLCDEBUG: %s\n", err), err)
+	}
+	return debug, err
+}
+
+func outPairs(conf *config.Config) []outPair {
 	var pairs []outPair
 	for _, sql := range conf.SQL {
 		if sql.Gen.Go != nil {
@@ -115,123 +273,144 @@ func Generate(e Env, dir string, stderr io.Writer) (map[string]string, error) {
 				Gen: config.SQLGen{Kotlin: sql.Gen.Kotlin},
 			})
 		}
+		if sql.Gen.JSON != nil {
+			pairs = append(pairs, outPair{
+				SQL: sql,
+				Gen: config.SQLGen{JSON: sql.Gen.JSON},
+			})
+		}
+		for _, p := range sql.Gen.Plugins {
+			pairs = append(pairs, outPair{
+				SQL: sql,
+				Gen: config.SQLGen{Plugins: []config.SQLPlugin{p}},
+			})
+		}
 	}
+	return pairs
+}
 
-	for _, sql := range pairs {
-		combo := config.Combine(conf, sql.SQL)
+// A target holds the resolved settings for generating a single package in a
+// single language
+type target struct {
+	dir       string
+	name      string
+	sql       outPair
+	combo     config.CombinedSettings
+	parseOpts opts.Parser
+}
 
-		// TODO: This feels like a hack that will bite us later
-		joined := make([]string, 0, len(sql.Schema))
-		for _, s := range sql.Schema {
-			joined = append(joined, filepath.Join(dir, s))
-		}
-		sql.Schema = joined
+func newTarget(dir string, conf *config.Config, sql outPair, debug opts.Debug) target {
+	combo := config.Combine(*conf, sql.SQL)
 
-		joined = make([]string, 0, len(sql.Queries))
-		for _, q := range sql.Queries {
-			joined = append(joined, filepath.Join(dir, q))
-		}
-		sql.Queries = joined
+	// TODO: This feels like a hack that will bite us later
+	joined := make([]string, 0, len(sql.Schema))
+	for _, s := range sql.Schema {
+		joined = append(joined, filepath.Join(dir, s))
+	}
+	sql.Schema = joined
 
-		var name string
-		parseOpts := opts.Parser{
-			Debug: debug,
-		}
-		if sql.Gen.Go != nil {
-			name = combo.Go.Package
-		} else if sql.Gen.Kotlin != nil {
-			parseOpts.UsePositionalParameters = true
-			name = combo.Kotlin.Package
-		}
-
-		var files map[string]string
-		var out string
-
-		// TODO: Note about how this will be going away
-		if sql.Engine == config.EngineMySQL {
-			result, errored := parseMySQL(e, name, dir, sql.SQL, combo, parseOpts, stderr)
-			if errored {
-				break
-			}
-			out = combo.Go.Out
-			files, err = golang.DeprecatedGenerate(result, combo)
-		} else {
-			result, errored := parse(e, name, dir, sql.SQL, combo, parseOpts, stderr)
-			if errored {
-				break
-			}
-			switch {
-			case sql.Gen.Go != nil:
-				out = combo.Go.Out
-				files, err = golang.Generate(result, combo)
-			case sql.Gen.Kotlin != nil:
-				out = combo.Kotlin.Out
-				files, err = kotlin.Generate(result, combo)
-			default:
-				panic("missing language backend")
-			}
-		}
-
-		if err != nil {
-			fmt.Fprintf(stderr, "# package %s\n", name)
-			fmt.Fprintf(stderr, "error generating code: %s\n", err)
-			errored = true
-			continue
-		}
-		for n, source := range files {
-			filename := filepath.Join(dir, out, n)
-			output[filename] = source
-		}
+	joined = make([]string, 0, len(sql.Queries))
+	for _, q := range sql.Queries {
+		joined = append(joined, filepath.Join(dir, q))
 	}
+	sql.Queries = joined
 
-	if errored {
-		return nil, fmt.Errorf("errored")
+	var name string
+	parseOpts := opts.Parser{
+		Debug: debug,
 	}
-	return output, nil
+	if sql.Gen.Go != nil {
+		name = combo.Go.Package
+	} else if sql.Gen.Kotlin != nil {
+		parseOpts.UsePositionalParameters = true
+		name = combo.Kotlin.Package
+	} else if sql.Gen.JSON != nil {
+		name = combo.JSON.Package
+	} else if len(sql.Gen.Plugins) > 0 {
+		name = sql.Gen.Plugins[0].Package
+	}
+
+	return target{
+		dir:       dir,
+		name:      name,
+		sql:       sql,
+		combo:     combo,
+		parseOpts: parseOpts,
+	}
+}
+
+func (t target) codegen(result *compiler.Result) (map[string]string, error) {
+	switch {
+	case t.sql.Gen.Go != nil:
+		return golang.Generate(result, t.combo)
+	case t.sql.Gen.Kotlin != nil:
+		return kotlin.Generate(result, t.combo)
+	case t.sql.Gen.JSON != nil:
+		return ir.Generate(result, t.combo)
+	case len(t.sql.Gen.Plugins) > 0:
+		return plugin.Generate(t.dir, result, t.combo, t.sql.Gen.Plugins[0])
+	default:
+		panic("missing language backend")
+	}
+}
+
+// The output directory, relative to the configuration file
+func (t target) out() string {
+	switch {
+	case t.sql.Gen.Go != nil:
+		return t.combo.Go.Out
+	case t.sql.Gen.Kotlin != nil:
+		return t.combo.Kotlin.Out
+	case t.sql.Gen.JSON != nil:
+		return t.combo.JSON.Out
+	case len(t.sql.Gen.Plugins) > 0:
+		return t.sql.Gen.Plugins[0].Out
+	}
+	return ""
+}
+
+// Key the generated files by their path on disk
+func (t target) outputPaths(files map[string]string) map[string]string {
+	output := make(map[string]string, len(files))
+	for n, source := range files {
+		output[filepath.Join(t.dir, t.out(), n)] = source
+	}
+	return output
 }
 
 // Experimental MySQL support
-func parseMySQL(e Env, name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts opts.Parser, stderr io.Writer) (golang.Generateable, bool) {
+func parseMySQL(e Env, name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts opts.Parser, r *reporter) (golang.Generateable, bool) {
 	q, err := mysql.GeneratePkg(name, sql.Schema, sql.Queries, combo)
 	if err != nil {
-		fmt.Fprintf(stderr, "# package %s\n", name)
-		if parserErr, ok := err.(*multierr.Error); ok {
-			for _, fileErr := range parserErr.Errs() {
-				printFileErr(stderr, dir, fileErr)
-			}
-		} else {
-			fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
-		}
+		r.packageErr(name, dir, "error parsing schema", err)
 		return nil, true
 	}
 	return q, false
 }
 
-func parse(e Env, name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts opts.Parser, stderr io.Writer) (*compiler.Result, bool) {
+func parse(e Env, name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts opts.Parser, r *reporter) (*compiler.Result, bool) {
+	c, errored := parseCatalog(name, dir, sql, combo, parserOpts, r)
+	if errored {
+		return nil, true
+	}
+	return parseQueries(c, name, dir, sql, parserOpts, r)
+}
+
+func parseCatalog(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts opts.Parser, r *reporter) (*compiler.Compiler, bool) {
 	c := compiler.NewCompiler(sql, combo)
 	if err := c.ParseCatalog(sql.Schema); err != nil {
-		fmt.Fprintf(stderr, "# package %s\n", name)
-		if parserErr, ok := err.(*multierr.Error); ok {
-			for _, fileErr := range parserErr.Errs() {
-				printFileErr(stderr, dir, fileErr)
-			}
-		} else {
-			fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
-		}
+		r.packageErr(name, dir, "error parsing schema", err)
 		return nil, true
 	}
 	if parserOpts.Debug.DumpCatalog {
 		debug.Dump(c.Catalog())
 	}
+	return c, false
+}
+
+func parseQueries(c *compiler.Compiler, name, dir string, sql config.SQL, parserOpts opts.Parser, r *reporter) (*compiler.Result, bool) {
 	if err := c.ParseQueries(sql.Queries, parserOpts); err != nil {
-		fmt.Fprintf(stderr, "# package %s\n", name)
-		if parserErr, ok := err.(*multierr.Error); ok {
-			for _, fileErr := range parserErr.Errs() {
-				printFileErr(stderr, dir, fileErr)
-			}
-		} else {
-			fmt.Fprintf(stderr, "error parsing queries: %s\n", err)
-		}
+		r.packageErr(name, dir, "error parsing queries", err)
 		return nil, true
 	}
 	return c.Result(), false
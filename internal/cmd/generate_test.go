@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindConfig(t *testing.T) {
+	root, err := ioutil.TempDir("", "sqlc-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	nested := filepath.Join(root, "db", "queries")
+	both := filepath.Join(root, "both")
+	for _, d := range []string{nested, both} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{
+		filepath.Join(root, "sqlc.yaml"),
+		filepath.Join(root, "db", "sqlc.prod.yaml"),
+		filepath.Join(both, "sqlc.yaml"),
+		filepath.Join(both, "sqlc.json"),
+	} {
+		if err := ioutil.WriteFile(f, []byte(`version: "1"`), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		name     string
+		dir      string
+		filename string
+		path     string
+	}{
+		{"current directory", root, "", filepath.Join(root, "sqlc.yaml")},
+		{"parent directory", nested, "", filepath.Join(root, "sqlc.yaml")},
+		{"relative file", nested, "../sqlc.prod.yaml", filepath.Join(root, "db", "sqlc.prod.yaml")},
+		{"absolute file", both, filepath.Join(root, "sqlc.yaml"), filepath.Join(root, "sqlc.yaml")},
+		{"both files present", both, "", ""},
+		{"missing file", root, "sqlc.dev.yaml", ""},
+	} {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := findConfig(&reporter{format: formatText, stderr: ioutil.Discard}, tt.dir, tt.filename)
+			if tt.path == "" {
+				if err == nil {
+					t.Fatalf("expected error; got %s", path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.path {
+				t.Errorf("expected %s; got %s", tt.path, path)
+			}
+		})
+	}
+}
+
+func TestGenerateJobsErrorOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-jobs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := `{"version": "1", "packages": [`
+	for i, name := range []string{"a", "b", "c", "d"} {
+		if i > 0 {
+			conf += ","
+		}
+		conf += `{"path": "` + name + `", "schema": "schema.sql", "queries": "` + name + `.sql"}`
+		query := "-- name: List :many\nSELECT id FROM authors;\n"
+		if name != "c" {
+			query = "-- name: List :many\nSELECT " + name + " FROM authors;\n"
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, name+".sql"), []byte(query), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conf += `]}`
+	for name, contents := range map[string]string{
+		"sqlc.json":  conf,
+		"schema.sql": `CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `# package a
+a.sql:2:8: column "a" does not exist
+# package b
+b.sql:2:8: column "b" does not exist
+# package d
+d.sql:2:8: column "d" does not exist
+`
+	for i := 0; i < 5; i++ {
+		var stderr bytes.Buffer
+		if _, err := Generate(Env{Jobs: 4}, dir, "", &stderr); err == nil {
+			t.Fatal("expected generate to fail")
+		}
+		if diff := cmp.Diff(expected, stderr.String()); diff != "" {
+			t.Fatalf("stderr differed (-want +got):\n%s", diff)
+		}
+	}
+}
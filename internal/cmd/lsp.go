@@ -0,0 +1,459 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/codegen/golang"
+	"github.com/kyleconroy/sqlc/internal/codegen/kotlin"
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/multierr"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlpath"
+)
+
+// LSP runs a language server on stdin and stdout. Every time a schema or
+// query file changes, the packages that include it are compiled again and the
+// errors are published as diagnostics. Hovering over a column or parameter
+// shows its type, and the definition of a table or column is the statement in
+// the schema files that created it.
+func LSP(e Env, dir, filename string, stdin io.Reader, stdout, stderr io.Writer) error {
+	s := &lspServer{
+		conn:      &rpcConn{r: bufio.NewReader(stdin), w: stdout},
+		dir:       dir,
+		filename:  filename,
+		stderr:    stderr,
+		docs:      map[string]string{},
+		published: map[string]bool{},
+	}
+	return s.run()
+}
+
+type lspServer struct {
+	conn     *rpcConn
+	dir      string
+	filename string
+	stderr   io.Writer
+
+	configPath string
+	pkgs       []*lspPackage
+	// The contents of the files open in the editor
+	docs map[string]string
+	// Files with diagnostics the editor is showing
+	published map[string]bool
+	shutdown  bool
+}
+
+type lspPackage struct {
+	target
+	compiler *compiler.Compiler
+}
+
+func (s *lspServer) run() error {
+	for {
+		msg, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		result, rerr := s.handle(msg)
+		if msg.ID == nil {
+			continue
+		}
+		if rerr != nil {
+			err = s.conn.replyErr(msg.ID, rerr.Code, rerr.Message)
+		} else {
+			err = s.conn.reply(msg.ID, result)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *lspServer) handle(msg *rpcMessage) (interface{}, *rpcError) {
+	switch msg.Method {
+
+	case "initialize":
+		var params lspInitializeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		if path, err := uriToPath(params.RootURI); err == nil && path != "" {
+			s.dir = path
+		} else if params.RootPath != "" {
+			s.dir = params.RootPath
+		}
+		s.load()
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync": map[string]interface{}{
+					"openClose": true,
+					"change":    1, // Full
+					"save":      map[string]bool{"includeText": false},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]string{
+				"name":    "sqlc",
+				"version": version,
+			},
+		}, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		if path, err := uriToPath(params.TextDocument.URI); err == nil {
+			s.docs[path] = params.TextDocument.Text
+			s.changed(path)
+		}
+
+	case "textDocument/didChange":
+		var params lspDidChangeParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		path, err := uriToPath(params.TextDocument.URI)
+		if err != nil || len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		// Only full document sync is supported, so the last change holds
+		// the entire document
+		s.docs[path] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		s.changed(path)
+
+	case "textDocument/didSave", "textDocument/didClose":
+		var params lspDidOpenParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		if path, err := uriToPath(params.TextDocument.URI); err == nil {
+			if msg.Method == "textDocument/didClose" {
+				delete(s.docs, path)
+			}
+			s.changed(path)
+		}
+
+	case "textDocument/hover":
+		var params lspPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return s.hover(params), nil
+
+	case "textDocument/definition":
+		var params lspPositionParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		return s.definition(params), nil
+
+	default:
+		if msg.ID != nil {
+			return nil, &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + msg.Method}
+		}
+	}
+	return nil, nil
+}
+
+// Read the configuration file and compile every package
+func (s *lspServer) load() {
+	s.pkgs = nil
+	r := &reporter{format: formatText, stderr: s.stderr}
+	configPath, conf, err := readConfig(r, s.dir, s.filename)
+	if err != nil {
+		return
+	}
+	s.configPath = configPath
+	debug, err := debugFromEnv(r)
+	if err != nil {
+		return
+	}
+	for _, sql := range conf.SQL {
+		s.pkgs = append(s.pkgs, &lspPackage{
+			target: newTarget(filepath.Dir(configPath), conf, outPair{SQL: sql, Gen: sql.Gen}, debug),
+		})
+	}
+	s.compile(s.pkgs)
+}
+
+// Compile the packages that include path again
+func (s *lspServer) changed(path string) {
+	if path == s.configPath {
+		s.load()
+		return
+	}
+	var pkgs []*lspPackage
+	for _, pkg := range s.pkgs {
+		if includes(pkg.sql.Schema, path) || includes(pkg.sql.Queries, path) {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	s.compile(pkgs)
+}
+
+func includes(paths []string, path string) bool {
+	files, err := sqlpath.Glob(paths)
+	if err != nil {
+		return false
+	}
+	for _, f := range files {
+		if f == path {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *lspServer) compile(pkgs []*lspPackage) {
+	overlay := make(map[string]string, len(s.docs))
+	for path, src := range s.docs {
+		overlay[path] = src
+	}
+
+	diags := map[string][]lspDiagnostic{}
+	for _, pkg := range pkgs {
+		c := compiler.NewCompiler(pkg.sql.SQL, pkg.combo)
+		c.Overlay(overlay)
+		pkg.compiler = c
+
+		for _, paths := range [][]string{pkg.sql.Schema, pkg.sql.Queries} {
+			files, _ := sqlpath.Glob(paths)
+			for _, f := range files {
+				if _, ok := diags[f]; !ok {
+					diags[f] = []lspDiagnostic{}
+				}
+			}
+		}
+
+		var errs []*multierr.FileError
+		if err := c.ParseCatalog(pkg.sql.Schema); err != nil {
+			if merr, ok := err.(*multierr.Error); ok {
+				errs = append(errs, merr.Errs()...)
+			} else {
+				fmt.Fprintf(s.stderr, "# package %s\nerror parsing schema: %s\n", pkg.name, err)
+			}
+		}
+		if err := c.ParseQueries(pkg.sql.Queries, pkg.parseOpts); err != nil {
+			if merr, ok := err.(*multierr.Error); ok {
+				errs = append(errs, merr.Errs()...)
+			} else {
+				fmt.Fprintf(s.stderr, "# package %s\nerror parsing queries: %s\n", pkg.name, err)
+			}
+		}
+		for _, fileErr := range errs {
+			src := s.read(fileErr.Filename)
+			diags[fileErr.Filename] = append(diags[fileErr.Filename], lspDiagnostic{
+				Range: lspRange{
+					Start: lineColumnToPosition(src, fileErr.Line, fileErr.Column),
+					End:   lineColumnToPosition(src, fileErr.EndLine, fileErr.EndColumn),
+				},
+				Severity: lspSeverityError,
+				Code:     errCode(fileErr.Err),
+				Source:   "sqlc",
+				Message:  fileErr.Err.Error(),
+			})
+		}
+	}
+
+	files := make([]string, 0, len(diags))
+	for f := range diags {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		if len(diags[f]) == 0 && !s.published[f] {
+			continue
+		}
+		s.published[f] = len(diags[f]) > 0
+		s.conn.notify("textDocument/publishDiagnostics", lspPublishDiagnosticsParams{
+			URI:         pathToURI(f),
+			Diagnostics: diags[f],
+		})
+	}
+}
+
+// Return the contents of a file, preferring the version open in the editor
+func (s *lspServer) read(path string) string {
+	if src, ok := s.docs[path]; ok {
+		return src
+	}
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(blob)
+}
+
+// Find the reference under the cursor in a query file
+func (s *lspServer) reference(params lspPositionParams) (*lspPackage, string, *compiler.Reference) {
+	path, err := uriToPath(params.TextDocument.URI)
+	if err != nil {
+		return nil, "", nil
+	}
+	for _, pkg := range s.pkgs {
+		if pkg.compiler == nil || !includes(pkg.sql.Queries, path) {
+			continue
+		}
+		src := s.read(path)
+		offset := positionToOffset(src, params.Position)
+		var found *compiler.Reference
+		refs := pkg.compiler.References(src, pkg.parseOpts)
+		for i, ref := range refs {
+			if offset < ref.Location || offset >= ref.Location+ref.Length {
+				continue
+			}
+			// Prefer the innermost reference
+			if found == nil || ref.Length < found.Length {
+				found = &refs[i]
+			}
+		}
+		if found != nil {
+			return pkg, src, found
+		}
+	}
+	return nil, "", nil
+}
+
+func (s *lspServer) hover(params lspPositionParams) interface{} {
+	pkg, src, ref := s.reference(params)
+	if ref == nil {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString("```sql\n")
+	switch {
+	case ref.Param != nil:
+		name := fmt.Sprintf("$%d", ref.Param.Number)
+		if ref.Column != nil && ref.Column.Name != "" {
+			name += " " + ref.Column.Name
+		}
+		fmt.Fprintf(&b, "%s %s\n", name, sqlType(ref.Column))
+	case ref.Column != nil:
+		name := ref.Column.Name
+		if ref.Column.Table != nil {
+			name = ref.Column.Table.Name + "." + name
+		}
+		fmt.Fprintf(&b, "%s %s\n", name, sqlType(ref.Column))
+	default:
+		table, err := pkg.compiler.Catalog().GetTable(ref.Table)
+		if err != nil {
+			return nil
+		}
+		fmt.Fprintf(&b, "TABLE %s (\n", ref.Table.Name)
+		for i, col := range table.Columns {
+			sep := ","
+			if i == len(table.Columns)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(&b, "  %s %s%s\n", col.Name, sqlType(compiler.ConvertColumn(ref.Table, col)), sep)
+		}
+		b.WriteString(")\n")
+	}
+	b.WriteString("```")
+
+	if ref.Column != nil {
+		result := &compiler.Result{Catalog: pkg.compiler.Catalog()}
+		switch {
+		case pkg.sql.Gen.Go != nil:
+			param, column := overrideNames(ref)
+			goType := golang.QueryGoType(result, ref.Query.Name, param, column, ref.Column, pkg.combo)
+			fmt.Fprintf(&b, "\n\nGo type: `%s`", goType)
+		case pkg.sql.Gen.Kotlin != nil:
+			fmt.Fprintf(&b, "\n\nKotlin type: `%s`", kotlin.KotlinType(result, ref.Column, pkg.combo))
+		}
+	}
+
+	return lspHover{
+		Contents: lspMarkupContent{Kind: "markdown", Value: b.String()},
+		Range: &lspRange{
+			Start: offsetToPosition(src, ref.Location),
+			End:   offsetToPosition(src, ref.Location+ref.Length),
+		},
+	}
+}
+
+// The names per-query overrides use for the parameter or output column a
+// reference refers to, so that the hover shows the type sqlc generates. A
+// column reference is only matched to an output column of the same name and
+// table.
+func overrideNames(ref *compiler.Reference) (param, column string) {
+	if ref.Param != nil {
+		if ref.Column != nil && ref.Column.Name != "" {
+			return ref.Column.Name, ""
+		}
+		return fmt.Sprintf("dollar_%d", ref.Param.Number), ""
+	}
+	for _, c := range ref.Query.Columns {
+		if c.Name != ref.Column.Name {
+			continue
+		}
+		if (c.Table == nil) != (ref.Column.Table == nil) {
+			continue
+		}
+		if c.Table == nil || *c.Table == *ref.Column.Table {
+			return "", c.Name
+		}
+	}
+	return "", ""
+}
+
+func sqlType(col *compiler.Column) string {
+	if col == nil {
+		return "any"
+	}
+	typ := col.DataType
+	if col.IsArray {
+		typ += "[]"
+	}
+	if col.NotNull {
+		typ += " NOT NULL"
+	} else {
+		typ += " NULL"
+	}
+	return typ
+}
+
+func (s *lspServer) definition(params lspPositionParams) interface{} {
+	pkg, _, ref := s.reference(params)
+	if ref == nil || ref.Table == nil {
+		return nil
+	}
+	var column string
+	if ref.Column != nil {
+		column = ref.Column.Name
+	}
+	span, ok := pkg.compiler.Definition(ref.Table, column)
+	if !ok {
+		return nil
+	}
+	src := s.read(span.Filename)
+	return lspLocation{
+		URI: pathToURI(span.Filename),
+		Range: lspRange{
+			Start: offsetToPosition(src, span.Location),
+			End:   offsetToPosition(src, span.Location+span.Length),
+		},
+	}
+}
@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The subset of the Language Server Protocol 3.15 used by sqlc lsp
+// https://microsoft.github.io/language-server-protocol/specifications/specification-3-15/
+
+const (
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+type rpcMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// An rpcConn reads and writes JSON-RPC 2.0 messages framed with
+// Content-Length headers
+type rpcConn struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func (c *rpcConn) read() (*rpcMessage, error) {
+	header, err := textproto.NewReader(c.r).ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length: %w", err)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return nil, err
+	}
+	var msg rpcMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (c *rpcConn) write(msg *rpcMessage) error {
+	msg.JSONRPC = "2.0"
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+func (c *rpcConn) reply(id *json.RawMessage, result interface{}) error {
+	if result == nil {
+		// A null result must still be sent
+		result = json.RawMessage("null")
+	}
+	return c.write(&rpcMessage{ID: id, Result: result})
+}
+
+func (c *rpcConn) replyErr(id *json.RawMessage, code int, message string) error {
+	return c.write(&rpcMessage{ID: id, Error: &rpcError{Code: code, Message: message}})
+}
+
+func (c *rpcConn) notify(method string, params interface{}) error {
+	blob, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&rpcMessage{Method: method, Params: blob})
+}
+
+type lspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start lspPosition `json:"start"`
+	End   lspPosition `json:"end"`
+}
+
+type lspLocation struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+type lspDiagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+const lspSeverityError = 1
+
+type lspTextDocument struct {
+	URI  string `json:"uri"`
+	Text string `json:"text,omitempty"`
+}
+
+type lspInitializeParams struct {
+	RootURI  string `json:"rootUri"`
+	RootPath string `json:"rootPath"`
+}
+
+type lspDidOpenParams struct {
+	TextDocument lspTextDocument `json:"textDocument"`
+}
+
+type lspDidChangeParams struct {
+	TextDocument   lspTextDocument `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type lspPositionParams struct {
+	TextDocument lspTextDocument `json:"textDocument"`
+	Position     lspPosition     `json:"position"`
+}
+
+type lspPublishDiagnosticsParams struct {
+	URI         string          `json:"uri"`
+	Diagnostics []lspDiagnostic `json:"diagnostics"`
+}
+
+type lspMarkupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+type lspHover struct {
+	Contents lspMarkupContent `json:"contents"`
+	Range    *lspRange        `json:"range,omitempty"`
+}
+
+func uriToPath(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "file" {
+		return "", errors.New("unsupported URI scheme: " + u.Scheme)
+	}
+	return filepath.FromSlash(u.Path), nil
+}
+
+func pathToURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
+
+// LSP positions count UTF-16 code units from the start of the line
+func offsetToPosition(src string, offset int) lspPosition {
+	if offset > len(src) {
+		offset = len(src)
+	}
+	line := strings.Count(src[:offset], "\n")
+	start := strings.LastIndexByte(src[:offset], '\n') + 1
+	return lspPosition{Line: line, Character: utf16Len(src[start:offset])}
+}
+
+func positionToOffset(src string, pos lspPosition) int {
+	offset := 0
+	for i := 0; i < pos.Line; i++ {
+		next := strings.IndexByte(src[offset:], '\n')
+		if next < 0 {
+			return len(src)
+		}
+		offset += next + 1
+	}
+	for units := 0; units < pos.Character && offset < len(src); {
+		r, size := utf8.DecodeRuneInString(src[offset:])
+		if r == '\n' {
+			break
+		}
+		units += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+	return offset
+}
+
+// Convert a one-based line and a one-based column counted in runes, as used
+// by multierr.FileError, to an LSP position
+func lineColumnToPosition(src string, line, column int) lspPosition {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(src[offset:], '\n')
+		if next < 0 {
+			break
+		}
+		offset += next + 1
+	}
+	start := offset
+	for i := 1; i < column && offset < len(src); i++ {
+		r, size := utf8.DecodeRuneInString(src[offset:])
+		if r == '\n' {
+			break
+		}
+		offset += size
+	}
+	return lspPosition{Line: line - 1, Character: utf16Len(src[start:offset])}
+}
+
+func utf16Len(s string) int {
+	return len(utf16.Encode([]rune(s)))
+}
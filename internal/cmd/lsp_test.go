@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const lspConfig = `{
+  "version": "1",
+  "packages": [{
+    "path": "db",
+    "schema": "schema.sql",
+    "queries": "query.sql",
+    "engine": "postgresql",
+    "overrides": [
+      {"query": "GetAuthor", "param": "id", "go_type": "int32"},
+      {"query": "GetAuthor", "column": "bio", "go_type": "github.com/example/text.Bio"}
+    ]
+  }]
+}`
+
+const lspSchema = `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);
+`
+
+const lspQuery = `-- name: GetAuthor :one
+SELECT name, bio FROM authors
+WHERE id = $1 LIMIT 1;
+`
+
+func TestLSP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-lsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.json":  lspConfig,
+		"schema.sql": lspSchema,
+		"query.sql":  lspQuery,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	query := pathToURI(filepath.Join(dir, "query.sql"))
+	schema := pathToURI(filepath.Join(dir, "schema.sql"))
+	broken := strings.Replace(lspQuery, "bio", "nope", 1)
+
+	var stdin bytes.Buffer
+	in := &rpcConn{w: &stdin}
+	request := func(id int, method string, params interface{}) {
+		blob, _ := json.Marshal(params)
+		raw := json.RawMessage(fmt.Sprintf("%d", id))
+		in.write(&rpcMessage{ID: &raw, Method: method, Params: blob})
+	}
+	position := func(line, char int) interface{} {
+		return map[string]interface{}{
+			"textDocument": map[string]string{"uri": query},
+			"position":     map[string]int{"line": line, "character": char},
+		}
+	}
+	request(1, "initialize", map[string]string{"rootUri": pathToURI(dir)})
+	in.notify("textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]string{"uri": query, "text": broken},
+	})
+	in.notify("textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]string{"uri": query},
+		"contentChanges": []map[string]string{{"text": lspQuery}},
+	})
+	request(2, "textDocument/hover", position(2, 11))
+	request(3, "textDocument/hover", position(1, 14))
+	request(4, "textDocument/definition", position(1, 7))
+	request(5, "shutdown", nil)
+	in.notify("exit", nil)
+
+	var stdout bytes.Buffer
+	if err := LSP(Env{}, dir, "", &stdin, &stdout, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &rpcConn{r: bufio.NewReader(&stdout)}
+	var got []string
+	for {
+		msg, err := out.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Results are decoded as maps, so their keys are sorted
+		blob, _ := json.Marshal(msg.Result)
+		if msg.Method != "" {
+			blob = msg.Params
+		}
+		if msg.ID != nil && string(*msg.ID) == "1" {
+			// Only check the capabilities of the initialize response
+			var init struct {
+				Capabilities json.RawMessage
+			}
+			json.Unmarshal(blob, &init)
+			blob = init.Capabilities
+		}
+		got = append(got, strings.Replace(string(blob), dir, "$DIR", -1))
+	}
+
+	want := []string{
+		`{"definitionProvider":true,"hoverProvider":true,"textDocumentSync":{"change":1,"openClose":true,"save":{"includeText":false}}}`,
+		`{"uri":"file://$DIR/query.sql","diagnostics":[{"range":{"start":{"line":1,"character":13},"end":{"line":1,"character":17}},"severity":1,"code":"42703","source":"sqlc","message":"column \"nope\" does not exist"}]}`,
+		`{"uri":"file://$DIR/query.sql","diagnostics":[]}`,
+		"{\"contents\":{\"kind\":\"markdown\",\"value\":\"```sql\\n$1 id bigserial NOT NULL\\n```\\n\\nGo type: `int32`\"},\"range\":{\"end\":{\"character\":13,\"line\":2},\"start\":{\"character\":11,\"line\":2}}}",
+		"{\"contents\":{\"kind\":\"markdown\",\"value\":\"```sql\\nauthors.bio text NULL\\n```\\n\\nGo type: `text.Bio`\"},\"range\":{\"end\":{\"character\":16,\"line\":1},\"start\":{\"character\":13,\"line\":1}}}",
+		`{"range":{"end":{"character":6,"line":2},"start":{"character":2,"line":2}},"uri":"` + strings.Replace(schema, dir, "$DIR", -1) + `"}`,
+		`null`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+// Keep tests from filling the user's cache directory. Tests of the cache set
+// SQLCCACHE to a temporary directory.
+func TestMain(m *testing.M) {
+	os.Setenv("SQLCCACHE", "off")
+	os.Exit(m.Run())
+}
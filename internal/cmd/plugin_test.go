@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/plugin"
+)
+
+// TestPluginProcess isn't a real test. It's used as the plugin executable by
+// TestPlugin, the same way os/exec tests its helper processes.
+func TestPluginProcess(t *testing.T) {
+	if os.Getenv("SQLC_TEST_PLUGIN") != "1" {
+		return
+	}
+	var req plugin.Request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if req.Settings.Options["fail"] == true {
+		fmt.Fprintln(os.Stderr, "told to fail")
+		os.Exit(2)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s v%d\n", req.Settings.Engine, req.Settings.Package, req.Version)
+	for _, q := range req.Package.Queries {
+		fmt.Fprintf(&b, "%s %s params=%d columns=%d\n", q.Name, q.Cmd, len(q.Params), len(q.Columns))
+	}
+	json.NewEncoder(os.Stdout).Encode(plugin.Response{
+		Files: []plugin.File{{Name: "queries.txt", Contents: b.String()}},
+	})
+	os.Exit(0)
+}
+
+func TestPlugin(t *testing.T) {
+	os.Setenv("SQLC_TEST_PLUGIN", "1")
+	defer os.Unsetenv("SQLC_TEST_PLUGIN")
+
+	dir, err := ioutil.TempDir("", "sqlc-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	conf := func(options string) string {
+		return fmt.Sprintf(`{
+  "version": "2",
+  "plugins": [{"name": "txt", "cmd": %q, "args": ["-test.run=TestPluginProcess"]}],
+  "sql": [{
+    "schema": "schema.sql",
+    "queries": "query.sql",
+    "engine": "postgresql",
+    "gen": {"plugins": [{"plugin": "txt", "out": "gen", "options": %s}]}
+  }]
+}`, os.Args[0], options)
+	}
+	for name, contents := range map[string]string{
+		"sqlc.json":  conf(`{}`),
+		"schema.sql": `CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL);`,
+		"query.sql": `-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+`,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	output, err := Generate(Env{}, dir, "", &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	expected := map[string]string{
+		filepath.Join(dir, "gen", "queries.txt"): "postgresql gen v1\nGetAuthor :one params=1 columns=2\n",
+	}
+	if diff := cmp.Diff(expected, output); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "sqlc.json"), []byte(conf(`{"fail": true}`)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stderr.Reset()
+	if _, err := Generate(Env{}, dir, "", &stderr); err == nil {
+		t.Fatal("expected the plugin to fail")
+	}
+	if !strings.Contains(stderr.String(), "plugin txt: exit status 2: told to fail") {
+		t.Errorf("unexpected error output: %s", stderr.String())
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/compiler"
+)
+
+// Vet runs the enabled lint rules against every query in every package
+func Vet(e Env, dir, filename string, stderr io.Writer) error {
+	r, err := newReporter(e.Format, dir, stderr)
+	if err != nil {
+		return err
+	}
+	err = vet(e, dir, filename, r)
+	if err := r.flush(); err != nil {
+		return err
+	}
+	return err
+}
+
+func vet(e Env, dir, filename string, r *reporter) error {
+	configPath, conf, err := readConfig(r, dir, filename)
+	if err != nil {
+		return err
+	}
+	dir = filepath.Dir(configPath)
+
+	debug, err := debugFromEnv(r)
+	if err != nil {
+		return err
+	}
+
+	errored := false
+	for _, sql := range conf.SQL {
+		t := newTarget(dir, conf, outPair{SQL: sql, Gen: sql.Gen}, debug)
+
+		rules, err := compiler.Rules(sql.Vet)
+		if err != nil {
+			r.packageErr(t.name, dir, "error parsing sqlc.json", err)
+			errored = true
+			continue
+		}
+
+		c, failed := parseCatalog(t.name, dir, t.sql.SQL, t.combo, t.parseOpts, r)
+		if failed {
+			errored = true
+			continue
+		}
+		if err := c.Vet(rules, t.parseOpts); err != nil {
+			r.packageErr(t.name, dir, "error parsing queries", err)
+			errored = true
+		}
+	}
+
+	if errored {
+		return errors.New("errored")
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/codegen/golang"
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlpath"
+)
+
+// How often Watch checks the inputs for changes
+const watchInterval = 500 * time.Millisecond
+
+// A stamp records enough about a file to tell if it has changed
+type stamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// Return a stamp for every SQL file in paths. A missing file or directory
+// yields an empty set, so that the package is rebuilt once it reappears.
+func stampPaths(paths []string) map[string]stamp {
+	stamps := map[string]stamp{}
+	files, err := sqlpath.Glob(paths)
+	if err != nil {
+		return stamps
+	}
+	for _, f := range files {
+		stamps[f] = stampFile(f)
+	}
+	return stamps
+}
+
+func stampFile(path string) stamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return stamp{}
+	}
+	return stamp{size: info.Size(), modTime: info.ModTime()}
+}
+
+// Stamp the same files again
+func stampFiles(old map[string]stamp) map[string]stamp {
+	stamps := make(map[string]stamp, len(old))
+	for f := range old {
+		stamps[f] = stampFile(f)
+	}
+	return stamps
+}
+
+func sameStamps(a, b map[string]stamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+// A watchedTarget caches the compiled state of a single package between
+// builds
+type watchedTarget struct {
+	target
+	compiler *compiler.Compiler
+	schema   map[string]stamp
+	queries  map[string]stamp
+}
+
+// Watch runs Generate, then keeps running and regenerates packages whenever
+// their schema, query or configuration files change. Only the packages whose
+// inputs changed are rebuilt, and when only query files changed the parsed
+// catalog is reused. Files are only written if their contents changed.
+func Watch(e Env, dir, filename string, stdout, stderr io.Writer) error {
+	r, err := newReporter(e.Format, dir, stderr)
+	if err != nil {
+		return err
+	}
+	w := &watcher{env: e, dir: dir, filename: filename, stdout: stdout, r: r}
+	for {
+		w.poll()
+		time.Sleep(watchInterval)
+	}
+}
+
+type watcher struct {
+	env      Env
+	dir      string
+	filename string
+	stdout   io.Writer
+	r        *reporter
+
+	configPath string
+	// The configuration file and the files it includes
+	configStamps map[string]stamp
+	targets      []*watchedTarget
+}
+
+func (w *watcher) poll() {
+	if w.configPath == "" || !sameStamps(w.configStamps, stampFiles(w.configStamps)) {
+		w.reload()
+		w.r.flush()
+		return
+	}
+	var rebuilt bool
+	for _, t := range w.targets {
+		schema := stampPaths(t.sql.Schema)
+		queries := stampPaths(t.sql.Queries)
+		schemaChanged := !sameStamps(schema, t.schema)
+		queriesChanged := !sameStamps(queries, t.queries)
+		if !schemaChanged && !queriesChanged {
+			continue
+		}
+		t.schema, t.queries = schema, queries
+		if schemaChanged {
+			t.compiler = nil
+		}
+		w.build(t)
+		rebuilt = true
+	}
+	if rebuilt {
+		w.r.flush()
+		fmt.Fprintln(w.stdout, "sqlc: waiting for changes")
+	}
+}
+
+// Read the configuration file and rebuild every package from scratch
+func (w *watcher) reload() {
+	w.targets = nil
+	configPath, conf, err := readConfig(w.r, w.dir, w.filename)
+	if configPath != "" {
+		w.configPath = configPath
+		w.configStamps = map[string]stamp{configPath: stampFile(configPath)}
+		if conf != nil {
+			for _, f := range conf.Files {
+				w.configStamps[f] = stampFile(f)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(w.stdout, "sqlc: waiting for changes")
+		return
+	}
+	debug, err := debugFromEnv(w.r)
+	if err != nil {
+		return
+	}
+	for _, pair := range outPairs(conf) {
+		t := &watchedTarget{target: newTarget(filepath.Dir(configPath), conf, pair, debug)}
+		t.schema = stampPaths(t.sql.Schema)
+		t.queries = stampPaths(t.sql.Queries)
+		w.targets = append(w.targets, t)
+		w.build(t)
+	}
+	fmt.Fprintln(w.stdout, "sqlc: waiting for changes")
+}
+
+func (w *watcher) build(t *watchedTarget) {
+	var files map[string]string
+	var err error
+
+	// TODO: Note about how this will be going away
+	if t.sql.Engine == config.EngineMySQL {
+		result, failed := parseMySQL(w.env, t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, w.r)
+		if failed {
+			return
+		}
+		files, err = golang.DeprecatedGenerate(result, t.combo)
+	} else {
+		if t.compiler == nil {
+			c, failed := parseCatalog(t.name, t.dir, t.sql.SQL, t.combo, t.parseOpts, w.r)
+			if failed {
+				return
+			}
+			t.compiler = c
+		}
+		result, failed := parseQueries(t.compiler, t.name, t.dir, t.sql.SQL, t.parseOpts, w.r)
+		if failed {
+			return
+		}
+		files, err = t.codegen(result)
+	}
+	if err != nil {
+		w.r.packageErr(t.name, t.dir, "error generating code", err)
+		return
+	}
+
+	output := t.outputPaths(files)
+	filenames := make([]string, 0, len(output))
+	for filename := range output {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		source := output[filename]
+		if existing, err := ioutil.ReadFile(filename); err == nil && string(existing) == source {
+			continue
+		}
+		os.MkdirAll(filepath.Dir(filename), 0755)
+		if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
+			fmt.Fprintf(w.r.stderr, "%s: %s\n", filename, err)
+			continue
+		}
+		fmt.Fprintf(w.stdout, "sqlc: wrote %s\n", strings.TrimPrefix(filename, t.dir+"/"))
+	}
+}
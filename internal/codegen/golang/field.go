@@ -1,9 +1,18 @@
 package golang
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"sort"
 	"strings"
+	"sync"
+	"text/template"
+	"unicode"
+
+	"github.com/kyleconroy/sqlc/internal/codegen"
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/config"
 )
 
 type Field struct {
@@ -22,5 +31,137 @@ func (gf Field) Tag() string {
 		return ""
 	}
 	sort.Strings(tags)
-	return strings.Join(tags, ",")
+	return strings.Join(tags, " ")
+}
+
+// The data available to struct_tags templates
+type tagData struct {
+	// The name of the column, e.g. author_id
+	Name string
+	// The table the column belongs to, if any
+	Table   string
+	DBType  string
+	NotNull bool
+	IsArray bool
+}
+
+// Parsed struct_tags templates, keyed by their text
+var tagTemplates sync.Map
+
+func tagTemplate(text string) (*template.Template, error) {
+	if t, ok := tagTemplates.Load(text); ok {
+		return t.(*template.Template), nil
+	}
+	t, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	tagTemplates.Store(text, t)
+	return t, nil
+}
+
+// Every struct_tags template must parse and render
+func validateStructTags(settings config.CombinedSettings) error {
+	for key, text := range settings.Go.StructTags {
+		t, err := tagTemplate(text)
+		if err == nil {
+			err = t.Execute(ioutil.Discard, tagData{})
+		}
+		if err != nil {
+			return fmt.Errorf("struct_tags: %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// The tags of the field generated for col, which is called name in the
+// struct. Tags from the struct_tags templates replace the default json and db
+// tags, and tags from overrides replace both.
+func buildTags(r *compiler.Result, col *compiler.Column, name string, overrideTags map[string]string, settings config.CombinedSettings) map[string]string {
+	tags := map[string]string{}
+	if settings.Go.EmitDBTags {
+		tags["db:"] = name
+	}
+	if settings.Go.EmitJSONTags {
+		json := jsonTagName(name, settings)
+		if settings.Go.JSONTagsOmitEmpty && !col.NotNull {
+			json += ",omitempty"
+		}
+		tags["json:"] = json
+	}
+
+	data := tagData{
+		Name:    name,
+		DBType:  col.DataType,
+		NotNull: col.NotNull,
+		IsArray: col.IsArray,
+	}
+	if col.Table != nil {
+		data.Table = col.Table.Name
+	}
+	for key, text := range settings.Go.StructTags {
+		t, err := tagTemplate(text)
+		if err != nil {
+			continue
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			continue
+		}
+		if value := buf.String(); value != "" {
+			tags[key+":"] = value
+		} else {
+			delete(tags, key+":")
+		}
+	}
+
+	// The first matching override wins, as it does for types
+	for i := len(settings.Overrides) - 1; i >= 0; i-- {
+		if oride := settings.Overrides[i]; oride.Query == "" && matchesColumn(r, oride, col) {
+			for key, value := range oride.StructTags {
+				tags[key+":"] = value
+			}
+		}
+	}
+	for key, value := range overrideTags {
+		tags[key+":"] = value
+	}
+	return tags
+}
+
+func jsonTagName(name string, settings config.CombinedSettings) string {
+	switch settings.Go.JSONTagsCaseStyle {
+	case "snake":
+		return toSnakeCase(name)
+	case "camel":
+		return toCamelCase(name, false)
+	case "pascal":
+		return toCamelCase(name, true)
+	default:
+		return name
+	}
+}
+
+func toSnakeCase(s string) string {
+	out := ""
+	prev := rune(0)
+	for _, r := range s {
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev)) {
+			out += "_"
+		}
+		out += string(unicode.ToLower(r))
+		prev = r
+	}
+	return out
+}
+
+func toCamelCase(s string, pascal bool) string {
+	out := ""
+	for _, p := range strings.Split(s, "_") {
+		out += strings.Title(p)
+	}
+	if !pascal && out != "" {
+		out = codegen.LowerTitle(out)
+	}
+	return out
 }
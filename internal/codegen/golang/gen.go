@@ -5,12 +5,15 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/kyleconroy/sqlc/internal/codegen"
 	"github.com/kyleconroy/sqlc/internal/compiler"
 	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/metadata"
 )
 
 type Generateable interface {
@@ -35,12 +38,26 @@ import (
 {{end}}
 
 {{define "dbCode"}}
+{{if eq .SQLPackage "pgx/v4"}}
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	{{- if .UsesBatch}}
+	SendBatch(context.Context, *pgx.Batch) pgx.BatchResults
+	{{- end}}
+	{{- if .UsesCopyFrom}}
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	{{- end}}
+}
+{{else}}
 type DBTX interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
 	PrepareContext(context.Context, string) (*sql.Stmt, error)
 	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
 	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
 }
+{{end}}
 
 func New(db DBTX) *Queries {
 	return &Queries{db: db}
@@ -50,10 +67,10 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
-	{{- if eq (len .GoQueries) 0 }}
+	{{- if eq (len .PreparedQueries) 0 }}
 	_ = err
 	{{- end }}
-	{{- range .GoQueries }}
+	{{- range .PreparedQueries }}
 	if q.{{.FieldName}}, err = db.PrepareContext(ctx, {{.ConstantName}}); err != nil {
 		return nil, fmt.Errorf("error preparing query {{.MethodName}}: %w", err)
 	}
@@ -63,7 +80,7 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
-	{{- range .GoQueries }}
+	{{- range .PreparedQueries }}
 	if q.{{.FieldName}} != nil {
 		if cerr := q.{{.FieldName}}.Close(); cerr != nil {
 			err = fmt.Errorf("error closing {{.FieldName}}: %w", cerr)
@@ -112,23 +129,90 @@ type Queries struct {
 
     {{- if .EmitPreparedQueries}}
 	tx         *sql.Tx
-	{{- range .GoQueries}}
+	{{- range .PreparedQueries}}
 	{{.FieldName}}  *sql.Stmt
 	{{- end}}
 	{{- end}}
 }
 
-func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+func (q *Queries) WithTx(tx {{if eq .SQLPackage "pgx/v4"}}pgx.Tx{{else}}*sql.Tx{{end}}) *Queries {
 	return &Queries{
 		db: tx,
      	{{- if .EmitPreparedQueries}}
 		tx: tx,
-		{{- range .GoQueries}}
+		{{- range .PreparedQueries}}
 		{{.FieldName}}: q.{{.FieldName}},
 		{{- end}}
 		{{- end}}
 	}
 }
+
+{{if and .UsesCopyFrom (ne .SQLPackage "pgx/v4")}}
+// copyFrom inserts n rows using multi-row INSERT statements, each of which
+// is kept under the server's max_allowed_packet
+func (q *Queries) copyFrom(ctx context.Context, insert, values string, n int, row func(int) []interface{}) (int64, error) {
+	var maxPacket int
+	if err := q.db.QueryRowContext(ctx, "SELECT @@max_allowed_packet").Scan(&maxPacket); err != nil {
+		return 0, err
+	}
+	var total int64
+	for i := 0; i < n; {
+		var query strings.Builder
+		query.WriteString(insert)
+		size := len(insert)
+		var args []interface{}
+		for ; i < n; i++ {
+			vals := row(i)
+			rowSize := len(values) + 1
+			for _, v := range vals {
+				rowSize += copyFromValueSize(v)
+			}
+			if len(args) > 0 && (size+rowSize > maxPacket || len(args)+len(vals) > 65535) {
+				break
+			}
+			if len(args) > 0 {
+				query.WriteString(",")
+			}
+			query.WriteString(values)
+			size += rowSize
+			args = append(args, vals...)
+		}
+		result, err := q.db.ExecContext(ctx, query.String(), args...)
+		if err != nil {
+			return total, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return total, err
+		}
+		total += affected
+	}
+	return total, nil
+}
+
+// copyFromValueSize estimates the number of bytes v takes up in a query
+// packet
+func copyFromValueSize(v interface{}) int {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return 2
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		if dv, err := valuer.Value(); err == nil {
+			rv = reflect.ValueOf(dv)
+		}
+	}
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice:
+		return rv.Len() + 11
+	default:
+		return 16
+	}
+}
+{{end}}
 {{end}}
 
 {{define "interfaceFile"}}// Code generated by sqlc. DO NOT EDIT.
@@ -161,7 +245,13 @@ type Querier interface {
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error)
 	{{- end}}
 	{{- if eq .Cmd ":execresult"}}
-	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (sql.Result, error)
+	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{if eq $.SQLPackage "pgx/v4"}}pgconn.CommandTag{{else}}sql.Result{{end}}, error)
+	{{- end}}
+	{{- if .IsBatch}}
+	{{.MethodName}}(ctx context.Context, {{.Arg.SlicePair}}) *{{.MethodName}}BatchResults
+	{{- end}}
+	{{- if eq .Cmd ":copyfrom"}}
+	{{.MethodName}}(ctx context.Context, {{.Arg.SlicePair}}) (int64, error)
 	{{- end}}
 	{{- end}}
 }
@@ -213,7 +303,7 @@ type {{.Name}} struct { {{- range .Fields}}
   {{- if .Comment}}
   {{comment .Comment}}{{else}}
   {{- end}}
-  {{.Name}} {{.Type}} {{if or ($.EmitJSONTags) ($.EmitDBTags)}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
@@ -236,21 +326,23 @@ import (
 
 {{define "queryCode"}}
 {{range .GoQueries}}
-{{if $.OutputQuery .SourceName}}
+{{if and ($.OutputQuery .SourceName) (not .IsBatch)}}
+{{if ne .Cmd ":copyfrom"}}
 const {{.ConstantName}} = {{$.Q}}-- name: {{.MethodName}} {{.Cmd}}
 {{escape .SQL}}
 {{$.Q}}
+{{end}}
 
 {{if .Arg.EmitStruct}}
 type {{.Arg.Type}} struct { {{- range .Arg.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if or ($.EmitJSONTags) ($.EmitDBTags)}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
 
 {{if .Ret.EmitStruct}}
 type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if or ($.EmitJSONTags) ($.EmitDBTags)}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
@@ -261,6 +353,8 @@ type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error) {
   	{{- if $.EmitPreparedQueries}}
 	row := q.queryRow(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+	{{- else if eq $.SQLPackage "pgx/v4"}}
+	row := q.db.QueryRow(ctx, {{.ConstantName}}, {{.Arg.Params}})
 	{{- else}}
 	row := q.db.QueryRowContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
 	{{- end}}
@@ -276,6 +370,8 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Ty
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error) {
   	{{- if $.EmitPreparedQueries}}
 	rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- else if eq $.SQLPackage "pgx/v4"}}
+	rows, err := q.db.Query(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
@@ -295,9 +391,11 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.
 		}
 		items = append(items, {{.Ret.Name}})
 	}
+	{{- if ne $.SQLPackage "pgx/v4"}}
 	if err := rows.Close(); err != nil {
 		return nil, err
 	}
+	{{- end}}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
@@ -311,6 +409,8 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
   	{{- if $.EmitPreparedQueries}}
 	_, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- else if eq $.SQLPackage "pgx/v4"}}
+	_, err := q.db.Exec(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	_, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
@@ -324,27 +424,242 @@ func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
 func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
   	{{- if $.EmitPreparedQueries}}
 	result, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- else if eq $.SQLPackage "pgx/v4"}}
+	result, err := q.db.Exec(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	result, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
 	if err != nil {
 		return 0, err
 	}
+	{{- if eq $.SQLPackage "pgx/v4"}}
+	return result.RowsAffected(), nil
+	{{- else}}
 	return result.RowsAffected()
+	{{- end}}
 }
 {{end}}
 
 {{if eq .Cmd ":execresult"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (sql.Result, error) {
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{if eq $.SQLPackage "pgx/v4"}}pgconn.CommandTag{{else}}sql.Result{{end}}, error) {
   	{{- if $.EmitPreparedQueries}}
 	return q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- else if eq $.SQLPackage "pgx/v4"}}
+	return q.db.Exec(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	return q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
 }
 {{end}}
+
+{{if eq .Cmd ":copyfrom"}}
+{{if eq $.SQLPackage "pgx/v4"}}
+// iteratorFor{{.MethodName}} implements pgx.CopyFromSource
+type iteratorFor{{.MethodName}} struct {
+	rows                 []{{.Arg.Type}}
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorFor{{.MethodName}}) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorFor{{.MethodName}}) Values() ([]interface{}, error) {
+	return []interface{}{
+	{{- if .Arg.Struct}}
+	{{- range .Arg.Struct.Fields}}
+		r.rows[0].{{.Name}},
+	{{- end}}
+	{{- else}}
+		r.rows[0],
+	{{- end}}
+	}, nil
+}
+
+func (r iteratorFor{{.MethodName}}) Err() error {
+	return nil
+}
+{{end}}
+
+{{range .Comments}}//{{.}}
+{{end -}}
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.SlicePair}}) (int64, error) {
+	{{- if eq $.SQLPackage "pgx/v4"}}
+	return q.db.CopyFrom(ctx, pgx.Identifier{ {{- .CopyFromIdentifier -}} }, []string{ {{- .CopyFromColumnNames -}} }, &iteratorFor{{.MethodName}}{rows: {{.Arg.Name}}})
+	{{- else}}
+	return q.copyFrom(ctx, {{.CopyFromInsert}}, {{.CopyFromValues}}, len({{.Arg.Name}}), func(i int) []interface{} {
+		return []interface{}{
+		{{- if .Arg.Struct}}
+		{{- $arg := .Arg.Name}}
+		{{- range .Arg.Struct.Fields}}
+			{{$arg}}[i].{{.Name}},
+		{{- end}}
+		{{- else}}
+			{{.Arg.Name}}[i],
+		{{- end}}
+		}
+	})
+	{{- end}}
+}
+{{end}}
+{{end}}
+{{end}}
+{{end}}
+
+{{define "batchFile"}}// Code generated by sqlc. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "batchCode" . }}
+{{end}}
+
+{{define "batchCode"}}
+var (
+	ErrBatchAlreadyClosed = errors.New("batch already closed")
+)
+
+{{range .GoQueries}}
+{{if .IsBatch}}
+const {{.ConstantName}} = {{$.Q}}-- name: {{.MethodName}} {{.Cmd}}
+{{escape .SQL}}
+{{$.Q}}
+
+type {{.MethodName}}BatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+{{if .Arg.EmitStruct}}
+type {{.Arg.Type}} struct { {{- range .Arg.Struct.Fields}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{- end}}
+}
+{{end}}
+
+{{if .Ret.EmitStruct}}
+type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{- end}}
+}
+{{end}}
+
+{{range .Comments}}//{{.}}
+{{end -}}
+func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.SlicePair}}) *{{.MethodName}}BatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range {{.Arg.Name}} {
+		vals := []interface{}{
+		{{- if .Arg.Struct}}
+		{{- range .Arg.Struct.Fields}}
+			a.{{.Name}},
+		{{- end}}
+		{{- else}}
+			a,
+		{{- end}}
+		}
+		batch.Queue({{.ConstantName}}, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &{{.MethodName}}BatchResults{br, len({{.Arg.Name}}), false}
+}
+
+{{if eq .Cmd ":batchexec"}}
+func (b *{{.MethodName}}BatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+{{end}}
+
+{{if eq .Cmd ":batchone"}}
+func (b *{{.MethodName}}BatchResults) QueryRow(f func(int, {{.Ret.Type}}, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var {{.Ret.Name}} {{.Ret.Type}}
+		if b.closed {
+			if f != nil {
+				f(t, {{.Ret.Name}}, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan({{.Ret.Scan}})
+		if f != nil {
+			f(t, {{.Ret.Name}}, err)
+		}
+	}
+}
+{{end}}
+
+{{if eq .Cmd ":batchmany"}}
+func (b *{{.MethodName}}BatchResults) Query(f func(int, []{{.Ret.Type}}, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		{{- if $.EmitEmptySlices}}
+		items := []{{.Ret.Type}}{}
+		{{else}}
+		var items []{{.Ret.Type}}
+		{{end -}}
+		if b.closed {
+			if f != nil {
+				f(t, items, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		err := func() error {
+			rows, err := b.br.Query()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var {{.Ret.Name}} {{.Ret.Type}}
+				if err := rows.Scan({{.Ret.Scan}}); err != nil {
+					return err
+				}
+				items = append(items, {{.Ret.Name}})
+			}
+			return rows.Err()
+		}()
+		if f != nil {
+			f(t, items, err)
+		}
+	}
+}
+{{end}}
+
+func (b *{{.MethodName}}BatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
 {{end}}
 {{end}}
 {{end}}
@@ -358,7 +673,7 @@ type tmplCtx struct {
 	GoQueries []Query
 	Settings  config.Config
 
-	// TODO: Race conditions
+	// The file being rendered. Each file gets its own copy of the context.
 	SourceName string
 
 	EmitJSONTags        bool
@@ -366,24 +681,97 @@ type tmplCtx struct {
 	EmitPreparedQueries bool
 	EmitInterface       bool
 	EmitEmptySlices     bool
+
+	// Either database/sql, the default, or pgx/v4
+	SQLPackage   string
+	UsesBatch    bool
+	UsesCopyFrom bool
 }
 
 func (t *tmplCtx) OutputQuery(sourceName string) bool {
 	return t.SourceName == sourceName
 }
 
+func (t *tmplCtx) PreparedQueries() []Query {
+	return preparedQueries(t.GoQueries)
+}
+
 func DeprecatedGenerate(r Generateable, settings config.CombinedSettings) (map[string]string, error) {
 	return generate(settings, r.Enums(settings), r.Structs(settings), r.GoQueries(settings))
 }
 
 func Generate(r *compiler.Result, settings config.CombinedSettings) (map[string]string, error) {
+	if err := validateQuerySettings(r, settings); err != nil {
+		return nil, err
+	}
+	if err := validateStructTags(settings); err != nil {
+		return nil, err
+	}
 	enums := buildEnums(r, settings)
 	structs := buildStructs(r, settings)
 	queries := buildQueries(r, settings, structs)
 	return generate(settings, enums, structs, queries)
 }
 
+func usesBatch(queries []Query) bool {
+	for _, q := range queries {
+		if q.IsBatch() {
+			return true
+		}
+	}
+	return false
+}
+
+func usesCopyFrom(queries []Query) bool {
+	for _, q := range queries {
+		if q.Cmd == metadata.CmdCopyFrom {
+			return true
+		}
+	}
+	return false
+}
+
+// The queries with a prepared statement. :copyfrom queries are rewritten for
+// each call, so they are never prepared.
+func preparedQueries(queries []Query) []Query {
+	var prepared []Query
+	for _, q := range queries {
+		if q.Cmd != metadata.CmdCopyFrom {
+			prepared = append(prepared, q)
+		}
+	}
+	return prepared
+}
+
+// Batch and :copyfrom queries take a slice of parameters. Batch queries are
+// sent with pgx.Batch, and :copyfrom queries with pgx's CopyFrom or, for
+// MySQL, multi-row INSERT statements.
+func validateQueryCommands(queries []Query, settings config.CombinedSettings) error {
+	pgx := settings.Go.SQLPackage == config.SQLPackagePGXV4
+	for _, q := range queries {
+		switch {
+		case q.IsBatch():
+			if !pgx {
+				return fmt.Errorf("query %q: %s requires sql_package %q", q.MethodName, q.Cmd, config.SQLPackagePGXV4)
+			}
+		case q.Cmd == metadata.CmdCopyFrom:
+			if !pgx && (settings.Package.Engine != config.EngineMySQLBeta || len(q.CopyFromColumns) == 0) {
+				return fmt.Errorf("query %q: %s requires sql_package %q or the %s engine", q.MethodName, q.Cmd, config.SQLPackagePGXV4, config.EngineMySQLBeta)
+			}
+		default:
+			continue
+		}
+		if q.Arg.isEmpty() {
+			return fmt.Errorf("query %q: %s requires at least one parameter", q.MethodName, q.Cmd)
+		}
+	}
+	return nil
+}
+
 func generate(settings config.CombinedSettings, enums []Enum, structs []Struct, queries []Query) (map[string]string, error) {
+	if err := validateQueryCommands(queries, settings); err != nil {
+		return nil, err
+	}
 	i := &importer{
 		Settings: settings,
 		Queries:  queries,
@@ -408,6 +796,9 @@ func generate(settings config.CombinedSettings, enums []Enum, structs []Struct,
 		EmitDBTags:          golang.EmitDBTags,
 		EmitPreparedQueries: golang.EmitPreparedQueries,
 		EmitEmptySlices:     golang.EmitEmptySlices,
+		SQLPackage:          golang.SQLPackage,
+		UsesBatch:           usesBatch(queries),
+		UsesCopyFrom:        usesCopyFrom(queries),
 		Q:                   "`",
 		Package:             golang.Package,
 		GoQueries:           queries,
@@ -415,50 +806,80 @@ func generate(settings config.CombinedSettings, enums []Enum, structs []Struct,
 		Structs:             structs,
 	}
 
-	output := map[string]string{}
-
-	execute := func(name, templateName string) error {
+	render := func(name, templateName string) (string, error) {
 		var b bytes.Buffer
 		w := bufio.NewWriter(&b)
-		tctx.SourceName = name
-		err := tmpl.ExecuteTemplate(w, templateName, &tctx)
+		ctx := tctx
+		ctx.SourceName = name
+		err := tmpl.ExecuteTemplate(w, templateName, &ctx)
 		w.Flush()
 		if err != nil {
-			return err
+			return "", err
 		}
 		code, err := format.Source(b.Bytes())
 		if err != nil {
 			fmt.Println(b.String())
-			return fmt.Errorf("source error: %w", err)
-		}
-		if !strings.HasSuffix(name, ".go") {
-			name += ".go"
+			return "", fmt.Errorf("source error: %w", err)
 		}
-		output[name] = string(code)
-		return nil
+		return string(code), nil
 	}
 
-	if err := execute("db.go", "dbFile"); err != nil {
-		return nil, err
+	type file struct {
+		name     string
+		template string
+		code     string
+		err      error
 	}
-	if err := execute("models.go", "modelsFile"); err != nil {
-		return nil, err
+	files := []*file{
+		{name: "db.go", template: "dbFile"},
+		{name: "models.go", template: "modelsFile"},
 	}
 	if golang.EmitInterface {
-		if err := execute("querier.go", "interfaceFile"); err != nil {
-			return nil, err
-		}
+		files = append(files, &file{name: "querier.go", template: "interfaceFile"})
+	}
+
+	if usesBatch(queries) {
+		files = append(files, &file{name: "batch.go", template: "batchFile"})
 	}
 
-	files := map[string]struct{}{}
+	// Batch queries are all written to batch.go
+	sources := map[string]struct{}{}
 	for _, gq := range queries {
-		files[gq.SourceName] = struct{}{}
+		if !gq.IsBatch() {
+			sources[gq.SourceName] = struct{}{}
+		}
+	}
+	var names []string
+	for source := range sources {
+		names = append(names, source)
+	}
+	sort.Strings(names)
+	for _, source := range names {
+		files = append(files, &file{name: source, template: "queryFile"})
 	}
 
-	for source := range files {
-		if err := execute(source, "queryFile"); err != nil {
-			return nil, err
+	// Files are rendered and formatted concurrently. Errors are returned in
+	// the order above so that they don't depend on scheduling.
+	var wg sync.WaitGroup
+	for _, f := range files {
+		wg.Add(1)
+		go func(f *file) {
+			defer wg.Done()
+			f.code, f.err = render(f.name, f.template)
+		}(f)
+	}
+	wg.Wait()
+
+	output := map[string]string{}
+	for _, f := range files {
+		if f.err != nil {
+			return nil, f.err
+		}
+		name := f.name
+		if !strings.HasSuffix(name, ".go") {
+			name += ".go"
 		}
+		output[name] = f.code
 	}
 	return output, nil
 }
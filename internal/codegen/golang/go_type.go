@@ -1,15 +1,27 @@
 package golang
 
 import (
+	"strings"
+
 	"github.com/kyleconroy/sqlc/internal/compiler"
 	"github.com/kyleconroy/sqlc/internal/config"
 )
 
+// GoType returns the Go type generated code uses for col
+func GoType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
+	return goType(r, col, settings)
+}
+
+// QueryGoType returns the Go type generated code uses for a parameter or
+// output column of query, taking per-query overrides into account
+func QueryGoType(r *compiler.Result, query, param, column string, col *compiler.Column, settings config.CombinedSettings) string {
+	return queryGoType(r, query, param, column, col, settings)
+}
+
 func goType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
 	// package overrides have a higher precedence
 	for _, oride := range settings.Overrides {
-		sameTable := sameTableName(col.Table, oride.Table, r.Catalog.DefaultSchema)
-		if oride.Column != "" && oride.ColumnName == col.Name && sameTable {
+		if oride.Query == "" && oride.GoTypeName != "" && matchesColumn(r, oride, col) {
 			return oride.GoTypeName
 		}
 	}
@@ -31,6 +43,33 @@ func goInnerType(r *compiler.Result, col *compiler.Column, settings config.Combi
 		}
 	}
 
+	if settings.Go.EmitPointersForNull && !notNull {
+		return nullPointerType(r, col, settings)
+	}
+	return engineType(r, col, settings)
+}
+
+// With emit_pointers_for_null_types, a nullable column is a pointer to the
+// type of the same column when it is not null, instead of a sql.Null* type.
+// Types which can already be nil are left as is.
+func nullPointerType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
+	notNullCol := *col
+	notNullCol.NotNull = true
+	typ := engineType(r, &notNullCol, settings)
+	switch {
+	case strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "map["):
+		return typ
+	case strings.HasPrefix(typ, "sql.Null"), strings.HasPrefix(typ, "pgtype."):
+		// Types such as void are always nullable, as are the pgtype types
+		return typ
+	case typ == "interface{}", typ == "json.RawMessage", typ == "net.IP", typ == "net.HardwareAddr":
+		return typ
+	default:
+		return "*" + typ
+	}
+}
+
+func engineType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
 	// TODO: Extend the engine interface to handle types
 	switch settings.Package.Engine {
 	case config.EngineMySQL, config.EngineMySQLBeta:
@@ -43,3 +82,43 @@ func goInnerType(r *compiler.Result, col *compiler.Column, settings config.Combi
 		return "interface{}"
 	}
 }
+
+// Whether a column override applies to col
+func matchesColumn(r *compiler.Result, oride config.Override, col *compiler.Column) bool {
+	sameTable := sameTableName(col.Table, oride.Table, r.Catalog.DefaultSchema)
+	return oride.Column != "" && oride.ColumnName == col.Name && sameTable
+}
+
+// Whether a per-query override applies to the named parameter or output
+// column of query
+func matchesQuery(oride config.Override, query, param, column string) bool {
+	if oride.Query != query {
+		return false
+	}
+	return (param != "" && oride.Param == param) || (column != "" && oride.Param == "" && oride.Column == column)
+}
+
+// Overrides that target a query's parameter or output column by name take
+// precedence over every other override
+func queryGoType(r *compiler.Result, query, param, column string, col *compiler.Column, settings config.CombinedSettings) string {
+	for _, oride := range settings.Overrides {
+		if oride.GoTypeName != "" && matchesQuery(oride, query, param, column) {
+			return oride.GoTypeName
+		}
+	}
+	return goType(r, col, settings)
+}
+
+// The struct tags set by the per-query overrides of a parameter or output
+// column
+func queryStructTags(query, param, column string, settings config.CombinedSettings) map[string]string {
+	tags := map[string]string{}
+	for i := len(settings.Overrides) - 1; i >= 0; i-- {
+		if oride := settings.Overrides[i]; matchesQuery(oride, query, param, column) {
+			for key, value := range oride.StructTags {
+				tags[key] = value
+			}
+		}
+	}
+	return tags
+}
@@ -47,11 +47,17 @@ type importer struct {
 	Structs  []Struct
 }
 
+// Whether the Go type typ, or the element type of a slice or pointer type,
+// starts with name
+func usesPrefix(typ, name string) bool {
+	typ = strings.TrimPrefix(typ, "[]")
+	return strings.HasPrefix(typ, name) || strings.HasPrefix(strings.TrimPrefix(typ, "*"), name)
+}
+
 func (i *importer) usesType(typ string) bool {
 	for _, strct := range i.Structs {
 		for _, f := range strct.Fields {
-			fType := strings.TrimPrefix(f.Type, "[]")
-			if strings.HasPrefix(fType, typ) {
+			if usesPrefix(f.Type, typ) {
 				return true
 			}
 		}
@@ -78,16 +84,34 @@ func (i *importer) Imports(filename string) [][]string {
 		return mergeImports(i.modelImports())
 	case "querier.go":
 		return mergeImports(i.interfaceImports())
+	case "batch.go":
+		return mergeImports(i.batchImports())
 	default:
 		return mergeImports(i.queryImports(filename))
 	}
 }
 
+func (i *importer) usesPGX() bool {
+	return i.Settings.Go.SQLPackage == config.SQLPackagePGXV4
+}
+
 func (i *importer) dbImports() fileImports {
+	if i.usesPGX() {
+		return fileImports{
+			Std: []string{"context"},
+			Dep: []string{"github.com/jackc/pgconn", "github.com/jackc/pgx/v4"},
+		}
+	}
 	std := []string{"context", "database/sql"}
-	if i.Settings.Go.EmitPreparedQueries {
+	if usesCopyFrom(i.Queries) {
+		std = append(std, "database/sql/driver")
+	}
+	if i.Settings.Go.EmitPreparedQueries && len(preparedQueries(i.Queries)) > 0 {
 		std = append(std, "fmt")
 	}
+	if usesCopyFrom(i.Queries) {
+		std = append(std, "reflect", "strings")
+	}
 	return fileImports{Std: std}
 }
 
@@ -95,12 +119,12 @@ func (i *importer) interfaceImports() fileImports {
 	uses := func(name string) bool {
 		for _, q := range i.Queries {
 			if !q.Ret.isEmpty() {
-				if strings.HasPrefix(q.Ret.Type(), name) {
+				if usesPrefix(q.Ret.Type(), name) {
 					return true
 				}
 			}
 			if !q.Arg.isEmpty() {
-				if strings.HasPrefix(q.Arg.Type(), name) {
+				if usesPrefix(q.Arg.Type(), name) {
 					return true
 				}
 			}
@@ -114,9 +138,14 @@ func (i *importer) interfaceImports() fileImports {
 	if uses("sql.Null") {
 		std["database/sql"] = struct{}{}
 	}
+	pkg := make(map[string]struct{})
 	for _, q := range i.Queries {
 		if q.Cmd == metadata.CmdExecResult {
-			std["database/sql"] = struct{}{}
+			if i.usesPGX() {
+				pkg["github.com/jackc/pgconn"] = struct{}{}
+			} else {
+				std["database/sql"] = struct{}{}
+			}
 		}
 	}
 	if uses("json.RawMessage") {
@@ -132,10 +161,9 @@ func (i *importer) interfaceImports() fileImports {
 		std["net"] = struct{}{}
 	}
 
-	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range i.Settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
 		overrideTypes[o.GoTypeName] = o.GoPackage
@@ -149,6 +177,9 @@ func (i *importer) interfaceImports() fileImports {
 	if uses("uuid.UUID") && !overrideUUID {
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
+	if uses("pgtype.") {
+		pkg["github.com/jackc/pgtype"] = struct{}{}
+	}
 
 	// Custom imports
 	for goType, importPath := range overrideTypes {
@@ -197,7 +228,7 @@ func (i *importer) modelImports() fileImports {
 	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range i.Settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
 		overrideTypes[o.GoTypeName] = o.GoPackage
@@ -213,6 +244,10 @@ func (i *importer) modelImports() fileImports {
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
 
+	if i.usesType("pgtype.") {
+		pkg["github.com/jackc/pgtype"] = struct{}{}
+	}
+
 	for goType, importPath := range overrideTypes {
 		if _, ok := std[importPath]; !ok && i.usesType(goType) {
 			pkg[importPath] = struct{}{}
@@ -237,36 +272,52 @@ func (i *importer) modelImports() fileImports {
 func (i *importer) queryImports(filename string) fileImports {
 	var gq []Query
 	for _, query := range i.Queries {
-		if query.SourceName == filename {
+		if query.SourceName == filename && !query.IsBatch() {
 			gq = append(gq, query)
 		}
 	}
+	return i.queryListImports(gq)
+}
+
+func (i *importer) batchImports() fileImports {
+	var bq []Query
+	for _, query := range i.Queries {
+		if query.IsBatch() {
+			bq = append(bq, query)
+		}
+	}
+	imps := i.queryListImports(bq)
+	imps.Std = append(imps.Std, "errors")
+	imps.Dep = append(imps.Dep, "github.com/jackc/pgx/v4")
+	sort.Strings(imps.Std)
+	sort.Strings(imps.Dep)
+	return imps
+}
 
+func (i *importer) queryListImports(gq []Query) fileImports {
 	uses := func(name string) bool {
 		for _, q := range gq {
 			if !q.Ret.isEmpty() {
 				if q.Ret.EmitStruct() {
 					for _, f := range q.Ret.Struct.Fields {
-						fType := strings.TrimPrefix(f.Type, "[]")
-						if strings.HasPrefix(fType, name) {
+						if usesPrefix(f.Type, name) {
 							return true
 						}
 					}
 				}
-				if strings.HasPrefix(q.Ret.Type(), name) {
+				if usesPrefix(q.Ret.Type(), name) {
 					return true
 				}
 			}
 			if !q.Arg.isEmpty() {
 				if q.Arg.EmitStruct() {
 					for _, f := range q.Arg.Struct.Fields {
-						fType := strings.TrimPrefix(f.Type, "[]")
-						if strings.HasPrefix(fType, name) {
+						if usesPrefix(f.Type, name) {
 							return true
 						}
 					}
 				}
-				if strings.HasPrefix(q.Arg.Type(), name) {
+				if usesPrefix(q.Arg.Type(), name) {
 					return true
 				}
 			}
@@ -312,9 +363,14 @@ func (i *importer) queryImports(filename string) fileImports {
 	if uses("sql.Null") {
 		std["database/sql"] = struct{}{}
 	}
+	pkg := make(map[string]struct{})
 	for _, q := range gq {
 		if q.Cmd == metadata.CmdExecResult {
-			std["database/sql"] = struct{}{}
+			if i.usesPGX() {
+				pkg["github.com/jackc/pgconn"] = struct{}{}
+			} else {
+				std["database/sql"] = struct{}{}
+			}
 		}
 	}
 	if uses("json.RawMessage") {
@@ -327,18 +383,20 @@ func (i *importer) queryImports(filename string) fileImports {
 		std["net"] = struct{}{}
 	}
 
-	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range i.Settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
 		overrideTypes[o.GoTypeName] = o.GoPackage
 	}
 
-	if sliceScan() {
+	if sliceScan() && !i.usesPGX() {
 		pkg["github.com/lib/pq"] = struct{}{}
 	}
+	if usesCopyFrom(gq) && i.usesPGX() {
+		pkg["github.com/jackc/pgx/v4"] = struct{}{}
+	}
 	_, overrideNullTime := overrideTypes["pq.NullTime"]
 	if uses("pq.NullTime") && !overrideNullTime {
 		pkg["github.com/lib/pq"] = struct{}{}
@@ -347,6 +405,9 @@ func (i *importer) queryImports(filename string) fileImports {
 	if uses("uuid.UUID") && !overrideUUID {
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
+	if uses("pgtype.") {
+		pkg["github.com/jackc/pgtype"] = struct{}{}
+	}
 
 	// Custom imports
 	for goType, importPath := range overrideTypes {
@@ -77,9 +77,9 @@ func mysqlType(r *compiler.Result, col *compiler.Column, settings config.Combine
 				case *catalog.Enum:
 					if t.Name == columnType {
 						if schema.Name == r.Catalog.DefaultSchema {
-							return StructName(t.Name, settings)
+							return EnumName(t.Name, settings)
 						}
-						return StructName(schema.Name+"_"+t.Name, settings)
+						return EnumName(schema.Name+"_"+t.Name, settings)
 					}
 				}
 			}
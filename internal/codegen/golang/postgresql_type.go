@@ -12,6 +12,7 @@ import (
 func postgresType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
 	columnType := col.DataType
 	notNull := col.NotNull || col.IsArray
+	pgx := settings.Go.SQLPackage == config.SQLPackagePGXV4
 
 	switch columnType {
 	case "serial", "serial4", "pg_catalog.serial4":
@@ -56,7 +57,13 @@ func postgresType(r *compiler.Result, col *compiler.Column, settings config.Comb
 		}
 		return "sql.NullFloat64" // TODO: Change to sql.NullFloat32 after updating the go.mod file
 
-	case "numeric", "pg_catalog.numeric", "money":
+	case "numeric", "pg_catalog.numeric":
+		if pgx {
+			return "pgtype.Numeric"
+		}
+		fallthrough
+
+	case "money":
 		// Since the Go standard library does not have a decimal type, lib/pq
 		// returns numerics as strings.
 		//
@@ -105,7 +112,16 @@ func postgresType(r *compiler.Result, col *compiler.Column, settings config.Comb
 	case "uuid":
 		return "uuid.UUID"
 
-	case "inet", "cidr":
+	case "inet":
+		if pgx {
+			return "pgtype.Inet"
+		}
+		return "net.IP"
+
+	case "cidr":
+		if pgx {
+			return "pgtype.CIDR"
+		}
 		return "net.IP"
 
 	case "macaddr", "macaddr8":
@@ -123,6 +139,9 @@ func postgresType(r *compiler.Result, col *compiler.Column, settings config.Comb
 		return "sql.NullString"
 
 	case "interval", "pg_catalog.interval":
+		if pgx {
+			return "pgtype.Interval"
+		}
 		if notNull {
 			return "int64"
 		}
@@ -155,9 +174,9 @@ func postgresType(r *compiler.Result, col *compiler.Column, settings config.Comb
 				case *catalog.Enum:
 					if rel.Name == t.Name && rel.Schema == schema.Name {
 						if schema.Name == r.Catalog.DefaultSchema {
-							return StructName(t.Name, settings)
+							return EnumName(t.Name, settings)
 						}
-						return StructName(schema.Name+"_"+t.Name, settings)
+						return EnumName(schema.Name+"_"+t.Name, settings)
 					}
 				case *catalog.CompositeType:
 					if notNull {
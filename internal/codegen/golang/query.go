@@ -1,12 +1,22 @@
 package golang
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/metadata"
+)
 
 type QueryValue struct {
 	Emit   bool
 	Name   string
 	Struct *Struct
 	Typ    string
+
+	// The driver package the generated code uses. pgx scans arrays natively,
+	// so they aren't wrapped in pq.Array.
+	SQLPackage string
 }
 
 func (v QueryValue) EmitStruct() bool {
@@ -28,6 +38,14 @@ func (v QueryValue) Pair() string {
 	return v.Name + " " + v.Type()
 }
 
+// The parameter of a batch query, a slice with one element per query
+func (v QueryValue) SlicePair() string {
+	if v.isEmpty() {
+		return ""
+	}
+	return v.Name + " []" + v.Type()
+}
+
 func (v QueryValue) Type() string {
 	if v.Typ != "" {
 		return v.Typ
@@ -38,20 +56,24 @@ func (v QueryValue) Type() string {
 	panic("no type for QueryValue: " + v.Name)
 }
 
+func (v QueryValue) wrapArray(typ string) bool {
+	return v.SQLPackage != config.SQLPackagePGXV4 && strings.HasPrefix(typ, "[]") && typ != "[]byte"
+}
+
 func (v QueryValue) Params() string {
 	if v.isEmpty() {
 		return ""
 	}
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
+		if v.wrapArray(v.Typ) {
 			out = append(out, "pq.Array("+v.Name+")")
 		} else {
 			out = append(out, v.Name)
 		}
 	} else {
 		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+			if v.wrapArray(f.Type) {
 				out = append(out, "pq.Array("+v.Name+"."+f.Name+")")
 			} else {
 				out = append(out, v.Name+"."+f.Name)
@@ -68,14 +90,14 @@ func (v QueryValue) Params() string {
 func (v QueryValue) Scan() string {
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
+		if v.wrapArray(v.Typ) {
 			out = append(out, "pq.Array(&"+v.Name+")")
 		} else {
 			out = append(out, "&"+v.Name)
 		}
 	} else {
 		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+			if v.wrapArray(f.Type) {
 				out = append(out, "pq.Array(&"+v.Name+"."+f.Name+")")
 			} else {
 				out = append(out, "&"+v.Name+"."+f.Name)
@@ -100,4 +122,48 @@ type Query struct {
 	SourceName   string
 	Ret          QueryValue
 	Arg          QueryValue
+
+	// The table, e.g. public.authors, and the columns a :copyfrom query
+	// inserts into
+	CopyFromTable   []string
+	CopyFromColumns []string
+}
+
+func (q Query) IsBatch() bool {
+	return metadata.IsBatch(q.Cmd)
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// The elements of the pgx.Identifier of a :copyfrom query's table
+func (q Query) CopyFromIdentifier() string {
+	return quoteAll(q.CopyFromTable)
+}
+
+// The elements of the column list passed to pgx's CopyFrom
+func (q Query) CopyFromColumnNames() string {
+	return quoteAll(q.CopyFromColumns)
+}
+
+// The start of the multi-row INSERT statement a :copyfrom query is sent as
+// by database/sql, as a Go string literal
+func (q Query) CopyFromInsert() string {
+	columns := make([]string, len(q.CopyFromColumns))
+	for i, c := range q.CopyFromColumns {
+		columns[i] = "`" + c + "`"
+	}
+	table := "`" + strings.Join(q.CopyFromTable, "`.`") + "`"
+	return strconv.Quote("INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES ")
+}
+
+// The placeholders of a single row of a :copyfrom query, as a Go string
+// literal
+func (q Query) CopyFromValues() string {
+	return strconv.Quote("(" + strings.TrimSuffix(strings.Repeat("?, ", len(q.CopyFromColumns)), ", ") + ")")
 }
@@ -31,12 +31,12 @@ func buildEnums(r *compiler.Result, settings config.CombinedSettings) []Enum {
 				enumName = schema.Name + "_" + enum.Name
 			}
 			e := Enum{
-				Name:    StructName(enumName, settings),
+				Name:    EnumName(enumName, settings),
 				Comment: enum.Comment,
 			}
 			for _, v := range enum.Vals {
 				e.Constants = append(e.Constants, Constant{
-					Name:  StructName(enumName+"_"+EnumReplace(v), settings),
+					Name:  EnumName(enumName+"_"+EnumReplace(v), settings),
 					Value: v,
 					Type:  e.Name,
 				})
@@ -73,17 +73,11 @@ func buildStructs(r *compiler.Result, settings config.CombinedSettings) []Struct
 				Comment: table.Comment,
 			}
 			for _, column := range table.Columns {
-				tags := map[string]string{}
-				if settings.Go.EmitDBTags {
-					tags["db:"] = column.Name
-				}
-				if settings.Go.EmitJSONTags {
-					tags["json:"] = column.Name
-				}
+				col := compiler.ConvertColumn(table.Rel, column)
 				s.Fields = append(s.Fields, Field{
-					Name:    StructName(column.Name, settings),
-					Type:    goType(r, compiler.ConvertColumn(table.Rel, column), settings),
-					Tags:    tags,
+					Name:    FieldName(column.Name, settings),
+					Type:    goType(r, col, settings),
+					Tags:    buildTags(r, col, column.Name, nil, settings),
 					Comment: column.Comment,
 				})
 			}
@@ -99,6 +93,10 @@ func buildStructs(r *compiler.Result, settings config.CombinedSettings) []Struct
 type goColumn struct {
 	id int
 	*compiler.Column
+	// The Go type of the field
+	typ string
+	// Struct tags set by per-query overrides
+	tags map[string]string
 }
 
 func columnName(c *compiler.Column, pos int) string {
@@ -108,22 +106,28 @@ func columnName(c *compiler.Column, pos int) string {
 	return fmt.Sprintf("column_%d", pos+1)
 }
 
-func paramName(p compiler.Parameter) string {
+// The name per-query overrides use for a parameter
+func paramOverrideName(p compiler.Parameter) string {
+	if p.Column.Name != "" {
+		return p.Column.Name
+	}
+	return fmt.Sprintf("dollar_%d", p.Number)
+}
+
+func paramName(p compiler.Parameter, settings config.CombinedSettings) string {
 	if p.Column.Name != "" {
-		return argName(p.Column.Name)
+		return argName(p.Column.Name, settings)
 	}
 	return fmt.Sprintf("dollar_%d", p.Number)
 }
 
-func argName(name string) string {
+func argName(name string, settings config.CombinedSettings) string {
 	out := ""
 	for i, p := range strings.Split(name, "_") {
 		if i == 0 {
 			out += strings.ToLower(p)
-		} else if p == "id" {
-			out += "ID"
 		} else {
-			out += strings.Title(p)
+			out += goWord(p, settings)
 		}
 	}
 	return out
@@ -139,21 +143,38 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 			continue
 		}
 
+		methodName := MethodName(query.Name, settings)
 		gq := Query{
 			Cmd:          query.Cmd,
-			ConstantName: codegen.LowerTitle(query.Name),
-			FieldName:    codegen.LowerTitle(query.Name) + "Stmt",
-			MethodName:   query.Name,
+			ConstantName: codegen.LowerTitle(methodName),
+			FieldName:    codegen.LowerTitle(methodName) + "Stmt",
+			MethodName:   methodName,
 			SourceName:   query.Filename,
 			SQL:          query.SQL,
 			Comments:     query.Comments,
 		}
+		if query.InsertIntoTable != nil {
+			if query.InsertIntoTable.Schema != "" {
+				gq.CopyFromTable = append(gq.CopyFromTable, query.InsertIntoTable.Schema)
+			}
+			gq.CopyFromTable = append(gq.CopyFromTable, query.InsertIntoTable.Name)
+			gq.CopyFromColumns = query.InsertIntoColumns
+		}
 
+		paramsName, rowName := queryStructNames(query.Name, settings)
+		if paramsName == "" {
+			paramsName = gq.MethodName + "Params"
+		}
+
+		paramType := func(p compiler.Parameter) string {
+			return queryGoType(r, query.Name, paramOverrideName(p), "", p.Column, settings)
+		}
 		if len(query.Params) == 1 {
 			p := query.Params[0]
 			gq.Arg = QueryValue{
-				Name: paramName(p),
-				Typ:  goType(r, p.Column, settings),
+				Name:       paramName(p, settings),
+				Typ:        paramType(p),
+				SQLPackage: settings.Go.SQLPackage,
 			}
 		} else if len(query.Params) > 1 {
 			var cols []goColumn
@@ -161,43 +182,36 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 				cols = append(cols, goColumn{
 					id:     p.Number,
 					Column: p.Column,
+					typ:    paramType(p),
+					tags:   queryStructTags(query.Name, paramOverrideName(p), "", settings),
 				})
 			}
 			gq.Arg = QueryValue{
-				Emit:   true,
-				Name:   "arg",
-				Struct: columnsToStruct(r, gq.MethodName+"Params", cols, settings),
+				Emit:       true,
+				Name:       "arg",
+				Struct:     columnsToStruct(r, paramsName, cols, settings),
+				SQLPackage: settings.Go.SQLPackage,
 			}
 		}
 
+		columnType := func(c *compiler.Column, i int) string {
+			return queryGoType(r, query.Name, "", columnName(c, i), c, settings)
+		}
 		if len(query.Columns) == 1 {
 			c := query.Columns[0]
 			gq.Ret = QueryValue{
-				Name: columnName(c, 0),
-				Typ:  goType(r, c, settings),
+				Name:       columnName(c, 0),
+				Typ:        columnType(c, 0),
+				SQLPackage: settings.Go.SQLPackage,
 			}
 		} else if len(query.Columns) > 1 {
 			var gs *Struct
 			var emit bool
 
-			for _, s := range structs {
-				if len(s.Fields) != len(query.Columns) {
-					continue
-				}
-				same := true
-				for i, f := range s.Fields {
-					c := query.Columns[i]
-					sameName := f.Name == StructName(columnName(c, i), settings)
-					sameType := f.Type == goType(r, c, settings)
-					sameTable := sameTableName(c.Table, s.Table, r.Catalog.DefaultSchema)
-					if !sameName || !sameType || !sameTable {
-						same = false
-					}
-				}
-				if same {
-					gs = &s
-					break
-				}
+			// Reuse a model struct with the same fields, unless the row struct
+			// has a configured name
+			if rowName == "" {
+				gs = matchingStruct(r, query, structs, columnType, settings)
 			}
 
 			if gs == nil {
@@ -206,15 +220,21 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 					columns = append(columns, goColumn{
 						id:     i,
 						Column: c,
+						typ:    columnType(c, i),
+						tags:   queryStructTags(query.Name, "", columnName(c, i), settings),
 					})
 				}
-				gs = columnsToStruct(r, gq.MethodName+"Row", columns, settings)
+				if rowName == "" {
+					rowName = gq.MethodName + "Row"
+				}
+				gs = columnsToStruct(r, rowName, columns, settings)
 				emit = true
 			}
 			gq.Ret = QueryValue{
-				Emit:   emit,
-				Name:   "i",
-				Struct: gs,
+				Emit:       emit,
+				Name:       "i",
+				Struct:     gs,
+				SQLPackage: settings.Go.SQLPackage,
 			}
 		}
 
@@ -224,6 +244,81 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 	return qs
 }
 
+func matchingStruct(r *compiler.Result, query *compiler.Query, structs []Struct, columnType func(*compiler.Column, int) string, settings config.CombinedSettings) *Struct {
+	for _, s := range structs {
+		if len(s.Fields) != len(query.Columns) {
+			continue
+		}
+		same := true
+		for i, f := range s.Fields {
+			c := query.Columns[i]
+			sameName := f.Name == FieldName(columnName(c, i), settings)
+			sameType := f.Type == columnType(c, i)
+			sameTable := sameTableName(c.Table, s.Table, r.Catalog.DefaultSchema)
+			noTags := len(queryStructTags(query.Name, "", columnName(c, i), settings)) == 0
+			if !sameName || !sameType || !sameTable || !noTags {
+				same = false
+			}
+		}
+		if same {
+			return &s
+		}
+	}
+	return nil
+}
+
+// The configured names of the params and row structs of a query, if any
+func queryStructNames(query string, settings config.CombinedSettings) (string, string) {
+	for _, qs := range settings.Go.QueryStructs {
+		if qs.Query == query {
+			return qs.Params, qs.Row
+		}
+	}
+	return "", ""
+}
+
+// Every query named by a package's per-query overrides and struct names must
+// exist, as must the parameter or column an override targets
+func validateQuerySettings(r *compiler.Result, settings config.CombinedSettings) error {
+	queries := map[string]*compiler.Query{}
+	for _, q := range r.Queries {
+		if q.Name != "" {
+			queries[q.Name] = q
+		}
+	}
+	for _, qs := range settings.Go.QueryStructs {
+		if _, ok := queries[qs.Query]; !ok {
+			return fmt.Errorf("query_structs: unknown query %q", qs.Query)
+		}
+	}
+	for _, o := range settings.Go.Overrides {
+		if o.Query == "" {
+			continue
+		}
+		q, ok := queries[o.Query]
+		if !ok {
+			return fmt.Errorf("overrides: unknown query %q", o.Query)
+		}
+		found := false
+		if o.Param != "" {
+			for _, p := range q.Params {
+				found = found || paramOverrideName(p) == o.Param
+			}
+			if !found {
+				return fmt.Errorf("overrides: query %q has no parameter named %q", o.Query, o.Param)
+			}
+			continue
+		}
+		for i, c := range q.Columns {
+			found = found || columnName(c, i) == o.Column
+		}
+		if !found {
+			return fmt.Errorf("overrides: query %q has no output column named %q", o.Query, o.Column)
+		}
+	}
+	return nil
+}
+
 // It's possible that this method will generate duplicate JSON tag values
 //
 //   Columns: count, count,   count_2
@@ -240,7 +335,7 @@ func columnsToStruct(r *compiler.Result, name string, columns []goColumn, settin
 	for i, c := range columns {
 		colName := columnName(c.Column, i)
 		tagName := colName
-		fieldName := StructName(colName, settings)
+		fieldName := FieldName(colName, settings)
 		// Track suffixes by the ID of the column, so that columns referring to the same numbered parameter can be
 		// reused.
 		suffix := 0
@@ -254,17 +349,14 @@ func columnsToStruct(r *compiler.Result, name string, columns []goColumn, settin
 			tagName = fmt.Sprintf("%s_%d", tagName, suffix)
 			fieldName = fmt.Sprintf("%s_%d", fieldName, suffix)
 		}
-		tags := map[string]string{}
-		if settings.Go.EmitDBTags {
-			tags["db:"] = tagName
-		}
-		if settings.Go.EmitJSONTags {
-			tags["json:"] = tagName
+		typ := c.typ
+		if typ == "" {
+			typ = goType(r, c.Column, settings)
 		}
 		gs.Fields = append(gs.Fields, Field{
 			Name: fieldName,
-			Type: goType(r, c.Column, settings),
-			Tags: tags,
+			Type: typ,
+			Tags: buildTags(r, c.Column, tagName, c.tags, settings),
 		})
 		seen[colName]++
 	}
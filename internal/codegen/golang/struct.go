@@ -3,6 +3,7 @@ package golang
 import (
 	"strings"
 
+	"github.com/kyleconroy/sqlc/internal/codegen"
 	"github.com/kyleconroy/sqlc/internal/config"
 	"github.com/kyleconroy/sqlc/internal/core"
 )
@@ -15,16 +16,56 @@ type Struct struct {
 }
 
 func StructName(name string, settings config.CombinedSettings) string {
+	return goName(config.RenameStruct, name, settings)
+}
+
+func FieldName(name string, settings config.CombinedSettings) string {
+	return goName(config.RenameField, name, settings)
+}
+
+func EnumName(name string, settings config.CombinedSettings) string {
+	return goName(config.RenameEnum, name, settings)
+}
+
+// MethodName returns the name of the method generated for a query. Query
+// names are already Go identifiers, so only rename rules and initialisms
+// apply.
+func MethodName(name string, settings config.CombinedSettings) string {
+	out := ""
+	for _, w := range codegen.CamelWords(settings.ApplyRenameRules(config.RenameMethod, name)) {
+		if isInitialism(w, settings) {
+			w = strings.ToUpper(w)
+		}
+		out += w
+	}
+	return out
+}
+
+func goName(kind, name string, settings config.CombinedSettings) string {
 	if rename := settings.Rename[name]; rename != "" {
 		return rename
 	}
+	name = settings.ApplyRenameRules(kind, name)
 	out := ""
 	for _, p := range strings.Split(name, "_") {
-		if p == "id" {
-			out += "ID"
-		} else {
-			out += strings.Title(p)
-		}
+		out += goWord(p, settings)
 	}
 	return out
 }
+
+// ID has always been written in upper case. The rest of the common
+// initialisms are opt-in, as they change the names of existing fields.
+func isInitialism(word string, settings config.CombinedSettings) bool {
+	if word == "id" {
+		return true
+	}
+	return codegen.IsInitialism(word, settings.Go.CommonInitialisms, settings.Go.Initialisms)
+}
+
+// A word of a snake_case name, as written in a Go identifier
+func goWord(word string, settings config.CombinedSettings) string {
+	if isInitialism(word, settings) {
+		return strings.ToUpper(word)
+	}
+	return strings.Title(word)
+}
@@ -0,0 +1,60 @@
+package codegen
+
+import "strings"
+
+// The words golint expects to be written in upper case, used when the
+// common_initialisms setting is enabled
+var commonInitialisms = map[string]bool{
+	"acl":   true,
+	"api":   true,
+	"ascii": true,
+	"cpu":   true,
+	"css":   true,
+	"dns":   true,
+	"eof":   true,
+	"guid":  true,
+	"html":  true,
+	"http":  true,
+	"https": true,
+	"id":    true,
+	"ip":    true,
+	"json":  true,
+	"lhs":   true,
+	"qps":   true,
+	"ram":   true,
+	"rhs":   true,
+	"rpc":   true,
+	"sla":   true,
+	"smtp":  true,
+	"sql":   true,
+	"ssh":   true,
+	"tcp":   true,
+	"tls":   true,
+	"ttl":   true,
+	"udp":   true,
+	"ui":    true,
+	"uid":   true,
+	"uri":   true,
+	"url":   true,
+	"utf8":  true,
+	"uuid":  true,
+	"vm":    true,
+	"xml":   true,
+	"xmpp":  true,
+	"xsrf":  true,
+	"xss":   true,
+}
+
+// IsInitialism reports whether word is one of the given initialisms, or one
+// of the common initialisms if common is set
+func IsInitialism(word string, common bool, initialisms []string) bool {
+	if common && commonInitialisms[strings.ToLower(word)] {
+		return true
+	}
+	for _, i := range initialisms {
+		if strings.EqualFold(i, word) {
+			return true
+		}
+	}
+	return false
+}
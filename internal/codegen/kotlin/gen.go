@@ -101,7 +101,7 @@ func jdbcSet(t ktType, idx int, name string) string {
 	if t.IsTime() {
 		return fmt.Sprintf("stmt.setObject(%d, %s)", idx, name)
 	}
-	return fmt.Sprintf("stmt.set%s(%d, %s)", t.Name, idx, name)
+	return fmt.Sprintf("stmt.%s(%d, %s)", t.jdbcSetter(), idx, name)
 }
 
 type Params struct {
@@ -150,7 +150,7 @@ func jdbcGet(t ktType, idx int) string {
 	if t.IsTime() {
 		return fmt.Sprintf(`results.getObject(%d, %s::class.java)`, idx, t.Name)
 	}
-	return fmt.Sprintf(`results.get%s(%d)`, t.Name, idx)
+	return fmt.Sprintf(`results.get%s(%d)`, t.jdbcType(), idx)
 }
 
 func (v QueryValue) ResultSet() string {
@@ -225,7 +225,7 @@ func buildEnums(r *compiler.Result, settings config.CombinedSettings) []Enum {
 				enumName = schema.Name + "_" + enum.Name
 			}
 			e := Enum{
-				Name:    DataClassName(enumName, settings),
+				Name:    EnumClassName(enumName, settings),
 				Comment: enum.Comment,
 			}
 			for _, v := range enum.Vals {
@@ -245,18 +245,43 @@ func buildEnums(r *compiler.Result, settings config.CombinedSettings) []Enum {
 }
 
 func DataClassName(name string, settings config.CombinedSettings) string {
+	return ktName(config.RenameStruct, name, settings)
+}
+
+func EnumClassName(name string, settings config.CombinedSettings) string {
+	return ktName(config.RenameEnum, name, settings)
+}
+
+func MemberName(name string, settings config.CombinedSettings) string {
+	member := ktName(config.RenameField, name, settings)
+	if settings.Rename[name] == "" {
+		// A leading initialism is written in lower case, e.g. urlPath
+		first := strings.Split(settings.ApplyRenameRules(config.RenameField, name), "_")[0]
+		if first != "" && ktWord(first, settings) != strings.Title(first) {
+			return strings.ToLower(member[:len(first)]) + member[len(first):]
+		}
+	}
+	return codegen.LowerTitle(member)
+}
+
+func ktName(kind, name string, settings config.CombinedSettings) string {
 	if rename := settings.Rename[name]; rename != "" {
 		return rename
 	}
+	name = settings.ApplyRenameRules(kind, name)
 	out := ""
 	for _, p := range strings.Split(name, "_") {
-		out += strings.Title(p)
+		out += ktWord(p, settings)
 	}
 	return out
 }
 
-func MemberName(name string, settings config.CombinedSettings) string {
-	return codegen.LowerTitle(DataClassName(name, settings))
+// A word of a snake_case name, as written in a Kotlin identifier
+func ktWord(word string, settings config.CombinedSettings) string {
+	if codegen.IsInitialism(word, settings.Kotlin.CommonInitialisms, settings.Kotlin.Initialisms) {
+		return strings.ToUpper(word)
+	}
+	return strings.Title(word)
 }
 
 func buildDataClasses(r *compiler.Result, settings config.CombinedSettings) []Struct {
@@ -326,6 +351,9 @@ func (t ktType) jdbcType() string {
 	if t.IsEnum || t.IsTime() {
 		return "Object"
 	}
+	if t.IsBigDecimal() {
+		return "BigDecimal"
+	}
 	return t.Name
 }
 
@@ -333,6 +361,16 @@ func (t ktType) IsTime() bool {
 	return t.Name == "LocalDate" || t.Name == "LocalDateTime" || t.Name == "LocalTime" || t.Name == "OffsetDateTime"
 }
 
+// BigDecimal is written with its package, as it isn't imported
+func (t ktType) IsBigDecimal() bool {
+	return t.Name == "java.math.BigDecimal"
+}
+
+// KotlinType returns the Kotlin type generated code uses for col
+func KotlinType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) string {
+	return makeType(r, col, settings).String()
+}
+
 func makeType(r *compiler.Result, col *compiler.Column, settings config.CombinedSettings) ktType {
 	typ, isEnum := ktInnerType(r, col, settings)
 	return ktType{
@@ -372,7 +410,7 @@ func ktInnerType(r *compiler.Result, col *compiler.Column, settings config.Combi
 	case "real", "pg_catalog.float4":
 		return "Float", false
 
-	case "pg_catalog.numeric":
+	case "numeric", "pg_catalog.numeric":
 		return "java.math.BigDecimal", false
 
 	case "bool", "pg_catalog.bool":
@@ -432,9 +470,9 @@ func ktInnerType(r *compiler.Result, col *compiler.Column, settings config.Combi
 				}
 				if columnType == enum.Name {
 					if schema.Name == r.Catalog.DefaultSchema {
-						return DataClassName(enum.Name, settings), true
+						return EnumClassName(enum.Name, settings), true
 					}
-					return DataClassName(schema.Name+"_"+enum.Name, settings), true
+					return EnumClassName(schema.Name+"_"+enum.Name, settings), true
 				}
 			}
 		}
@@ -475,23 +513,38 @@ func ktColumnsToStruct(r *compiler.Result, name string, columns []goColumn, sett
 	return &gs
 }
 
-func ktArgName(name string) string {
+func ktArgName(name string, settings config.CombinedSettings) string {
 	out := ""
 	for i, p := range strings.Split(name, "_") {
 		if i == 0 {
 			out += strings.ToLower(p)
 		} else {
-			out += strings.Title(p)
+			out += ktWord(p, settings)
 		}
 	}
 	return out
 }
 
-func ktParamName(c *compiler.Column, number int) string {
-	if c.Name != "" {
-		return ktArgName(c.Name)
+func ktParamName(settings config.CombinedSettings) func(*compiler.Column, int) string {
+	return func(c *compiler.Column, number int) string {
+		if c.Name != "" {
+			return ktArgName(c.Name, settings)
+		}
+		return fmt.Sprintf("dollar_%d", number)
 	}
-	return fmt.Sprintf("dollar_%d", number)
+}
+
+// The name of a query with rename rules and initialisms applied. Query names
+// are written in CamelCase.
+func ktMethodName(name string, settings config.CombinedSettings) string {
+	out := ""
+	for _, w := range codegen.CamelWords(settings.ApplyRenameRules(config.RenameMethod, name)) {
+		if codegen.IsInitialism(w, settings.Kotlin.CommonInitialisms, settings.Kotlin.Initialisms) {
+			w = strings.ToUpper(w)
+		}
+		out += w
+	}
+	return out
 }
 
 func ktColumnName(c *compiler.Column, pos int) string {
@@ -520,12 +573,13 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 			continue
 		}
 
+		name := ktMethodName(query.Name, settings)
 		gq := Query{
 			Cmd:          query.Cmd,
-			ClassName:    strings.Title(query.Name),
-			ConstantName: codegen.LowerTitle(query.Name),
-			FieldName:    codegen.LowerTitle(query.Name) + "Stmt",
-			MethodName:   codegen.LowerTitle(query.Name),
+			ClassName:    strings.Title(name),
+			ConstantName: codegen.LowerTitle(name),
+			FieldName:    codegen.LowerTitle(name) + "Stmt",
+			MethodName:   codegen.LowerTitle(name),
 			SourceName:   query.Filename,
 			SQL:          jdbcSQL(query.SQL),
 			Comments:     query.Comments,
@@ -538,7 +592,7 @@ func buildQueries(r *compiler.Result, settings config.CombinedSettings, structs
 				Column: p.Column,
 			})
 		}
-		params := ktColumnsToStruct(r, gq.ClassName+"Bindings", cols, settings, ktParamName)
+		params := ktColumnsToStruct(r, gq.ClassName+"Bindings", cols, settings, ktParamName(settings))
 		gq.Arg = Params{
 			Struct: params,
 		}
@@ -787,7 +841,7 @@ type ktTmplCtx struct {
 	Queries     []Query
 	Settings    config.Config
 
-	// TODO: Race conditions
+	// The file being rendered. Each file gets its own copy of the context.
 	SourceName string
 
 	EmitJSONTags        bool
@@ -853,8 +907,9 @@ func Generate(r *compiler.Result, settings config.CombinedSettings) (map[string]
 	execute := func(name string, t *template.Template) error {
 		var b bytes.Buffer
 		w := bufio.NewWriter(&b)
-		tctx.SourceName = name
-		err := t.Execute(w, tctx)
+		ctx := tctx
+		ctx.SourceName = name
+		err := t.Execute(w, ctx)
 		w.Flush()
 		if err != nil {
 			return err
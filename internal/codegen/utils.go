@@ -30,3 +30,20 @@ func EscapeBacktick(s string) string {
 func DoubleSlashComment(s string) string {
 	return "// " + strings.ReplaceAll(s, "\n", "\n// ")
 }
+
+// CamelWords splits a CamelCase identifier before each upper case letter, so
+// that GetAuthorUrl becomes Get, Author and Url
+func CamelWords(s string) []string {
+	var words []string
+	start := 0
+	for i, r := range s {
+		if i > start && unicode.IsUpper(r) {
+			words = append(words, s[start:i])
+			start = i
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
@@ -4,9 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/kyleconroy/sqlc/internal/metadata"
@@ -14,7 +12,6 @@ import (
 	"github.com/kyleconroy/sqlc/internal/multierr"
 	"github.com/kyleconroy/sqlc/internal/opts"
 	"github.com/kyleconroy/sqlc/internal/sql/ast"
-	"github.com/kyleconroy/sqlc/internal/sql/catalog"
 	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
 	"github.com/kyleconroy/sqlc/internal/sql/sqlpath"
 )
@@ -26,57 +23,30 @@ type Parser interface {
 	IsReservedKeyword(string) bool
 }
 
-// copied over from gen.go
-func structName(name string) string {
-	out := ""
-	for _, p := range strings.Split(name, "_") {
-		if p == "id" {
-			out += "ID"
-		} else {
-			out += strings.Title(p)
-		}
-	}
-	return out
-}
-
-var identPattern = regexp.MustCompile("[^a-zA-Z0-9_]+")
-
-func enumValueName(value string) string {
-	name := ""
-	id := strings.Replace(value, "-", "_", -1)
-	id = strings.Replace(id, ":", "_", -1)
-	id = strings.Replace(id, "/", "_", -1)
-	id = identPattern.ReplaceAllString(id, "")
-	for _, part := range strings.Split(id, "_") {
-		name += strings.Title(part)
-	}
-	return name
-}
-
-// end copypasta
-func parseCatalog(p Parser, c *catalog.Catalog, schemas []string) error {
+func (c *Compiler) parseCatalog(schemas []string) error {
 	files, err := sqlpath.Glob(schemas)
 	if err != nil {
 		return err
 	}
 	merr := multierr.New()
 	for _, filename := range files {
-		blob, err := ioutil.ReadFile(filename)
+		blob, err := c.readFile(filename)
 		if err != nil {
 			merr.Add(filename, "", 0, err)
 			continue
 		}
 		contents := migrations.RemoveRollbackStatements(string(blob))
-		stmts, err := p.Parse(strings.NewReader(contents))
+		stmts, err := c.parser.Parse(strings.NewReader(contents))
 		if err != nil {
 			merr.Add(filename, contents, 0, err)
 			continue
 		}
 		for i := range stmts {
-			if err := c.Update(stmts[i]); err != nil {
+			if err := c.catalog.Update(stmts[i], c); err != nil {
 				merr.Add(filename, contents, stmts[i].Pos(), err)
 				continue
 			}
+			c.define(filename, contents, stmts[i].Raw)
 		}
 	}
 	if len(merr.Errs()) > 0 {
@@ -85,7 +55,7 @@ func parseCatalog(p Parser, c *catalog.Catalog, schemas []string) error {
 	return nil
 }
 
-func (c *Compiler) parseQueries(o opts.Parser) (*Result, error) {
+func (c *Compiler) parseQueries(o opts.Parser, rules []Rule) (*Result, error) {
 	var q []*Query
 	merr := multierr.New()
 	set := map[string]struct{}{}
@@ -94,7 +64,7 @@ func (c *Compiler) parseQueries(o opts.Parser) (*Result, error) {
 		return nil, err
 	}
 	for _, filename := range files {
-		blob, err := ioutil.ReadFile(filename)
+		blob, err := c.readFile(filename)
 		if err != nil {
 			merr.Add(filename, "", 0, err)
 			continue
@@ -127,6 +97,11 @@ func (c *Compiler) parseQueries(o opts.Parser) (*Result, error) {
 				set[query.Name] = struct{}{}
 			}
 			query.Filename = filepath.Base(filename)
+			for _, rule := range rules {
+				for _, err := range rule.Check(c.catalog, stmt.Raw, query) {
+					merr.Add(filename, src, stmt.Raw.Pos(), err)
+				}
+			}
 			if query != nil {
 				q = append(q, query)
 			}
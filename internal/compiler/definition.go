@@ -0,0 +1,175 @@
+package compiler
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/kyleconroy/sqlc/internal/source"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+)
+
+// A Span is a range of bytes in a file
+type Span struct {
+	Filename string
+	Location int
+	Length   int
+}
+
+type tableDef struct {
+	table   Span
+	columns map[string]Span
+}
+
+func (c *Compiler) defKey(rel *ast.TableName) string {
+	schema := rel.Schema
+	if schema == "" {
+		schema = c.catalog.DefaultSchema
+	}
+	return schema + "." + rel.Name
+}
+
+// Record where the tables and columns created by a schema statement are
+// defined
+func (c *Compiler) define(filename, src string, raw *ast.RawStmt) {
+	if raw == nil {
+		return
+	}
+	start := raw.StmtLocation
+	end := start + raw.StmtLen
+	if raw.StmtLen == 0 || end > len(src) {
+		end = len(src)
+	}
+
+	switch n := raw.Stmt.(type) {
+
+	case *ast.CreateTableStmt:
+		if n.Name == nil {
+			return
+		}
+		loc := findToken(src, start, end, n.Name.Name)
+		if loc < 0 {
+			loc = start
+		}
+		def := &tableDef{
+			table:   tokenSpan(filename, src, loc),
+			columns: map[string]Span{},
+		}
+		// Column names follow the opening parenthesis
+		body := loc
+		if i := strings.IndexByte(src[loc:end], '('); i >= 0 {
+			body = loc + i
+		}
+		for _, col := range n.Cols {
+			if loc := findToken(src, body, end, col.Colname); loc >= 0 {
+				def.columns[col.Colname] = tokenSpan(filename, src, loc)
+			}
+		}
+		c.defs[c.defKey(n.Name)] = def
+
+	case *ast.ViewStmt:
+		if n.View == nil || n.View.Relname == nil {
+			return
+		}
+		c.defineRelation(filename, src, start, end, n.View)
+
+	case *ast.CreateTableAsStmt:
+		if n.Into == nil || n.Into.Rel == nil || n.Into.Rel.Relname == nil {
+			return
+		}
+		c.defineRelation(filename, src, start, end, n.Into.Rel)
+
+	case *ast.AlterTableStmt:
+		if n.Table == nil || n.Cmds == nil {
+			return
+		}
+		def, ok := c.defs[c.defKey(n.Table)]
+		if !ok {
+			return
+		}
+		from := findToken(src, start, end, n.Table.Name)
+		if from < 0 {
+			return
+		}
+		for _, item := range n.Cmds.Items {
+			cmd, ok := item.(*ast.AlterTableCmd)
+			if !ok || cmd.Subtype != ast.AT_AddColumn || cmd.Def == nil {
+				continue
+			}
+			if loc := findToken(src, from+len(n.Table.Name), end, cmd.Def.Colname); loc >= 0 {
+				def.columns[cmd.Def.Colname] = tokenSpan(filename, src, loc)
+			}
+		}
+
+	case *ast.DropTableStmt:
+		for _, rel := range n.Tables {
+			delete(c.defs, c.defKey(rel))
+		}
+
+	}
+}
+
+// Views and materialized views only record where the relation is defined, as
+// their columns come from a query
+func (c *Compiler) defineRelation(filename, src string, start, end int, rv *ast.RangeVar) {
+	rel := &ast.TableName{Name: *rv.Relname}
+	if rv.Schemaname != nil {
+		rel.Schema = *rv.Schemaname
+	}
+	loc := findToken(src, start, end, rel.Name)
+	if loc < 0 {
+		loc = start
+	}
+	c.defs[c.defKey(rel)] = &tableDef{
+		table:   tokenSpan(filename, src, loc),
+		columns: map[string]Span{},
+	}
+}
+
+// Definition returns where a table, or one of its columns if column isn't
+// empty, was created in the schema files
+func (c *Compiler) Definition(rel *ast.TableName, column string) (Span, bool) {
+	def, ok := c.defs[c.defKey(rel)]
+	if !ok {
+		return Span{}, false
+	}
+	if column == "" {
+		return def.table, true
+	}
+	span, ok := def.columns[column]
+	return span, ok
+}
+
+func tokenSpan(filename, src string, loc int) Span {
+	start, end := source.TokenSpan(src, loc)
+	return Span{Filename: filename, Location: start, Length: end - start}
+}
+
+// Return the offset of the first identifier in src[from:to] matching name,
+// ignoring comments, string literals and the schema of qualified names
+func findToken(src string, from, to int, name string) int {
+	for i := from; i < to; {
+		start, end := source.TokenSpan(src, i)
+		if start >= to || end <= start {
+			break
+		}
+		tok := src[start:end]
+		// Match the last part of a qualified name
+		offset := start
+		if dot := strings.LastIndexByte(tok, '.'); dot >= 0 && tok[0] != '"' && tok[0] != '\'' {
+			offset = start + dot + 1
+			tok = tok[dot+1:]
+		}
+		switch {
+		case strings.HasPrefix(tok, `"`):
+			if strings.Trim(tok, `"`) == name {
+				return offset
+			}
+		case tok != "" && (unicode.IsLetter(rune(tok[0])) || tok[0] == '_'):
+			if strings.EqualFold(tok, name) {
+				return offset
+			}
+		}
+		i = end
+	}
+	return -1
+}
@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"fmt"
+	"io/ioutil"
 
 	"github.com/kyleconroy/sqlc/internal/config"
 	"github.com/kyleconroy/sqlc/internal/engine/dolphin"
@@ -17,10 +18,15 @@ type Compiler struct {
 	catalog *catalog.Catalog
 	parser  Parser
 	result  *Result
+
+	// Contents to use instead of the files on disk
+	overlay map[string]string
+	// Where each table and column was created in the schema files
+	defs map[string]*tableDef
 }
 
 func NewCompiler(conf config.SQL, combo config.CombinedSettings) *Compiler {
-	c := &Compiler{conf: conf, combo: combo}
+	c := &Compiler{conf: conf, combo: combo, defs: map[string]*tableDef{}}
 	switch conf.Engine {
 	case config.EngineXLemon:
 		c.parser = sqlite.NewParser()
@@ -41,12 +47,26 @@ func (c *Compiler) Catalog() *catalog.Catalog {
 	return c.catalog
 }
 
+// Overlay sets the contents to use for files instead of reading them from
+// disk, keyed by path. Editors use this to compile files that haven't been
+// saved yet.
+func (c *Compiler) Overlay(files map[string]string) {
+	c.overlay = files
+}
+
+func (c *Compiler) readFile(filename string) ([]byte, error) {
+	if src, ok := c.overlay[filename]; ok {
+		return []byte(src), nil
+	}
+	return ioutil.ReadFile(filename)
+}
+
 func (c *Compiler) ParseCatalog(schema []string) error {
-	return parseCatalog(c.parser, c.catalog, schema)
+	return c.parseCatalog(schema)
 }
 
 func (c *Compiler) ParseQueries(queries []string, o opts.Parser) error {
-	r, err := c.parseQueries(o)
+	r, err := c.parseQueries(o, nil)
 	if err != nil {
 		return err
 	}
@@ -50,6 +50,15 @@ func (p paramSearch) Visit(node ast.Node) astutils.Visitor {
 	case *ast.A_Expr:
 		p.parent = node
 
+	case *ast.BoolExpr:
+		p.parent = node
+
+	case *ast.CaseExpr:
+		p.parent = node
+
+	case *ast.CoalesceExpr:
+		p.parent = node
+
 	case *ast.FuncCall:
 		p.parent = node
 
@@ -91,6 +100,9 @@ func (p paramSearch) Visit(node ast.Node) astutils.Visitor {
 	case *ast.ResTarget:
 		p.parent = node
 
+	case *ast.MinMaxExpr:
+		p.parent = node
+
 	case *ast.SelectStmt:
 		if n.LimitCount != nil {
 			p.limitCount = n.LimitCount
@@ -99,6 +111,9 @@ func (p paramSearch) Visit(node ast.Node) astutils.Visitor {
 			p.limitOffset = n.LimitOffset
 		}
 
+	case *ast.SubLink:
+		p.parent = node
+
 	case *ast.TypeCast:
 		p.parent = node
 
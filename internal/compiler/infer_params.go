@@ -0,0 +1,226 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/astutils"
+)
+
+// The tables a statement reads from, against which the column references
+// next to a parameter are resolved. A table referenced more than once under
+// the same name is only included once.
+func paramTables(qc *QueryCatalog, rvs []*ast.RangeVar) []*Table {
+	var tables []*Table
+	seen := map[ast.TableName]struct{}{}
+	for _, rv := range rvs {
+		if rv.Relname == nil {
+			continue
+		}
+		fqn, err := ParseTableName(rv)
+		if err != nil {
+			continue
+		}
+		table, err := qc.GetTable(fqn)
+		if err != nil {
+			continue
+		}
+		rel := *table.Rel
+		if rv.Alias != nil {
+			rel.Name = *rv.Alias.Aliasname
+		}
+		if _, ok := seen[rel]; ok {
+			continue
+		}
+		seen[rel] = struct{}{}
+		tables = append(tables, &Table{Rel: &rel, Columns: table.Columns})
+	}
+	return tables
+}
+
+// Whether node is the parameter, or a list containing it
+func isParamOperand(node ast.Node, ref *ast.ParamRef) bool {
+	if node == ast.Node(ref) {
+		return true
+	}
+	if list, ok := node.(*ast.List); ok {
+		for _, item := range list.Items {
+			if item == ast.Node(ref) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Infer the type of the first operand which isn't a parameter. The column is
+// named after the first column the operand references.
+func inferOperandType(qc *QueryCatalog, tables []*Table, operands []ast.Node) (*Column, error) {
+	for _, operand := range operands {
+		if list, ok := operand.(*ast.List); ok {
+			col, err := inferOperandType(qc, tables, list.Items)
+			if err != nil || !isUnknown(col) {
+				return col, err
+			}
+			continue
+		}
+		if _, ok := operand.(*ast.ParamRef); ok || operand == nil {
+			continue
+		}
+		col, err := inferType(qc, &ast.ResTarget{}, tables, operand)
+		if err != nil {
+			return nil, err
+		}
+		if isUnknown(col) {
+			continue
+		}
+		param := &Column{
+			Name:     operandName(operand),
+			DataType: col.DataType,
+			NotNull:  col.NotNull,
+			IsArray:  col.IsArray,
+			Table:    col.Table,
+		}
+		if _, ok := operand.(*ast.ColumnRef); !ok {
+			param.Table = nil
+		}
+		return param, nil
+	}
+	return &Column{DataType: "any"}, nil
+}
+
+func operandName(node ast.Node) string {
+	list := astutils.Search(node, func(node ast.Node) bool {
+		_, ok := node.(*ast.ColumnRef)
+		return ok
+	})
+	for _, item := range list.Items {
+		fields := stringSlice(item.(*ast.ColumnRef).Fields)
+		if len(fields) > 0 {
+			return fields[len(fields)-1]
+		}
+	}
+	return ""
+}
+
+// Infer the type of a parameter which is an operand of an operator from the
+// type of the other operand, e.g. `created_at > $1`, `id = ANY($1)` or
+// `data ->> $1`
+func inferExprParam(qc *QueryCatalog, tables []*Table, n *ast.A_Expr, ref *ast.ParamRef) (*Column, error) {
+	right := isParamOperand(n.Rexpr, ref)
+	other := n.Rexpr
+	if right {
+		other = n.Lexpr
+	}
+
+	op := astutils.Join(n.Name, "")
+	if right && n.Kind == ast.AEXPR_OP {
+		switch op {
+		case "->", "->>", "?":
+			return &Column{DataType: "text", NotNull: true}, nil
+		case "#>", "#>>", "?|", "?&":
+			return &Column{DataType: "text", NotNull: true, IsArray: true}, nil
+		}
+	}
+
+	col, err := inferOperandType(qc, tables, []ast.Node{other})
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case right && (n.Kind == ast.AEXPR_OP_ANY || n.Kind == ast.AEXPR_OP_ALL):
+		col.IsArray = true
+	case n.Kind == ast.AEXPR_OP && op == "+" && strings.HasPrefix(canonicalType(col.DataType), "time"):
+		// The only thing which can be added to a time or timestamp is an
+		// interval
+		return &Column{Name: col.Name, DataType: "interval", NotNull: true}, nil
+	case n.Kind == ast.AEXPR_LIKE, n.Kind == ast.AEXPR_ILIKE, n.Kind == ast.AEXPR_SIMILAR:
+		col.DataType = "text"
+	}
+	return col, nil
+}
+
+// Infer the type of a parameter which is an argument of a conditional
+// expression, e.g. `CASE WHEN $1 THEN ...`, `COALESCE($1, name)` or
+// `$1 IN (SELECT id FROM authors)`
+func inferParamType(qc *QueryCatalog, tables []*Table, parent ast.Node, ref *ast.ParamRef) (*Column, error) {
+	switch n := parent.(type) {
+
+	case *ast.BoolExpr:
+		return &Column{DataType: "bool", NotNull: true}, nil
+
+	case *ast.CaseExpr:
+		var conds, results []ast.Node
+		for _, item := range n.Args.Items {
+			if when, ok := item.(*ast.CaseWhen); ok {
+				conds = append(conds, when.Expr)
+				results = append(results, when.Result)
+			}
+		}
+		if !isEmpty(n.Defresult) {
+			results = append(results, n.Defresult)
+		}
+		switch {
+		case n.Arg == ast.Node(ref):
+			return inferOperandType(qc, tables, conds)
+		case isParamOperand(&ast.List{Items: conds}, ref):
+			if !isEmpty(n.Arg) {
+				return inferOperandType(qc, tables, []ast.Node{n.Arg})
+			}
+			return &Column{DataType: "bool", NotNull: true}, nil
+		default:
+			return inferOperandType(qc, tables, results)
+		}
+
+	case *ast.CoalesceExpr:
+		return inferOperandType(qc, tables, n.Args.Items)
+
+	case *ast.MinMaxExpr:
+		return inferOperandType(qc, tables, n.Args.Items)
+
+	case *ast.SubLink:
+		if n.Testexpr != ast.Node(ref) {
+			break
+		}
+		cols, err := outputColumns(qc, n.Subselect)
+		if err != nil || len(cols) == 0 {
+			break
+		}
+		return &Column{
+			Name:     cols[0].Name,
+			DataType: cols[0].DataType,
+			NotNull:  cols[0].NotNull,
+			IsArray:  cols[0].IsArray,
+			Table:    cols[0].Table,
+		}, nil
+
+	}
+	return &Column{DataType: "any"}, nil
+}
+
+// Whether typ is a polymorphic type such as anyelement. Unlike these, the
+// arguments of type any are unrelated to each other.
+func isPolymorphic(typ string) bool {
+	return typ != "any" && strings.HasPrefix(typ, "any")
+}
+
+// The type of a polymorphic function argument, such as anyelement, taken
+// from the other arguments of the same polymorphic type
+func polymorphicArgType(paramType string, declared []string, args []*Column) *Column {
+	for i, typ := range declared {
+		if isUnknown(args[i]) || !isPolymorphic(typ) {
+			continue
+		}
+		elem := typ != "anyarray" && typ != "anycompatiblearray"
+		want := paramType != "anyarray" && paramType != "anycompatiblearray"
+		col := &Column{DataType: args[i].DataType, NotNull: true, IsArray: args[i].IsArray}
+		switch {
+		case elem && !want:
+			col.IsArray = true
+		case !elem && want:
+			col.IsArray = false
+		}
+		return col
+	}
+	return nil
+}
@@ -0,0 +1,556 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/astutils"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
+	"github.com/kyleconroy/sqlc/internal/sql/lang"
+)
+
+// The canonical name of the common spellings of a built-in type, so that
+// column types can be compared with the argument types of functions
+var canonicalTypes = map[string]string{
+	"int2":              "smallint",
+	"smallserial":       "smallint",
+	"serial2":           "smallint",
+	"int":               "integer",
+	"int4":              "integer",
+	"serial":            "integer",
+	"serial4":           "integer",
+	"mediumint":         "integer",
+	"int8":              "bigint",
+	"bigserial":         "bigint",
+	"serial8":           "bigint",
+	"decimal":           "numeric",
+	"dec":               "numeric",
+	"fixed":             "numeric",
+	"float4":            "real",
+	"float":             "double precision",
+	"float8":            "double precision",
+	"double":            "double precision",
+	"bool":              "boolean",
+	"varchar":           "text",
+	"bpchar":            "text",
+	"character varying": "text",
+	"char":              "text",
+	"character":         "text",
+	"string":            "text",
+	"timestamp":         "timestamp without time zone",
+	"timestamptz":       "timestamp with time zone",
+	"datetime":          "timestamp without time zone",
+	"time":              "time without time zone",
+	"timetz":            "time with time zone",
+}
+
+func canonicalType(dt string) string {
+	dt = strings.TrimPrefix(dt, "pg_catalog.")
+	if name, ok := canonicalTypes[dt]; ok {
+		return name
+	}
+	return dt
+}
+
+// The order in which numeric types are promoted by arithmetic operators
+var numericRanks = map[string]int{
+	"smallint":         1,
+	"integer":          2,
+	"bigint":           3,
+	"numeric":          4,
+	"real":             5,
+	"double precision": 6,
+}
+
+// Functions which can return NULL even when all of their arguments are
+// non-null, including aggregates which return NULL when there are no input
+// rows
+var nullableFunctions = map[string]struct{}{
+	"array_agg":        {},
+	"array_position":   {},
+	"avg":              {},
+	"bit_and":          {},
+	"bit_or":           {},
+	"bool_and":         {},
+	"bool_or":          {},
+	"every":            {},
+	"first_value":      {},
+	"json_agg":         {},
+	"json_object_agg":  {},
+	"jsonb_agg":        {},
+	"jsonb_object_agg": {},
+	"lag":              {},
+	"last_value":       {},
+	"lead":             {},
+	"max":              {},
+	"min":              {},
+	"nth_value":        {},
+	"nullif":           {},
+	"regexp_match":     {},
+	"stddev":           {},
+	"stddev_pop":       {},
+	"stddev_samp":      {},
+	"string_agg":       {},
+	"sum":              {},
+	"var_pop":          {},
+	"var_samp":         {},
+	"variance":         {},
+	"xmlagg":           {},
+}
+
+// Functions which never return NULL, even when an argument is NULL
+var nonNullFunctions = map[string]struct{}{
+	"concat":             {},
+	"concat_ws":          {},
+	"count":              {},
+	"json_build_array":   {},
+	"json_build_object":  {},
+	"jsonb_build_array":  {},
+	"jsonb_build_object": {},
+}
+
+// Window functions which return a value for every row of a partition
+var rankingFunctions = map[string]struct{}{
+	"cume_dist":    {},
+	"dense_rank":   {},
+	"ntile":        {},
+	"percent_rank": {},
+	"rank":         {},
+	"row_number":   {},
+}
+
+// Strict functions which only return NULL when one of their arguments is
+// NULL. Any other function is assumed to be able to return NULL.
+var strictFunctions = map[string]struct{}{
+	"abs":                   {},
+	"age":                   {},
+	"btrim":                 {},
+	"ceil":                  {},
+	"ceiling":               {},
+	"char_length":           {},
+	"character_length":      {},
+	"date_part":             {},
+	"date_trunc":            {},
+	"decode":                {},
+	"encode":                {},
+	"floor":                 {},
+	"gen_random_uuid":       {},
+	"generate_series":       {},
+	"initcap":               {},
+	"left":                  {},
+	"length":                {},
+	"lower":                 {},
+	"lpad":                  {},
+	"ltrim":                 {},
+	"md5":                   {},
+	"mod":                   {},
+	"now":                   {},
+	"octet_length":          {},
+	"power":                 {},
+	"random":                {},
+	"repeat":                {},
+	"replace":               {},
+	"reverse":               {},
+	"right":                 {},
+	"round":                 {},
+	"rpad":                  {},
+	"rtrim":                 {},
+	"sign":                  {},
+	"split_part":            {},
+	"sqrt":                  {},
+	"strpos":                {},
+	"substr":                {},
+	"substring":             {},
+	"to_char":               {},
+	"to_timestamp":          {},
+	"transaction_timestamp": {},
+	"trim":                  {},
+	"trunc":                 {},
+	"upper":                 {},
+}
+
+func unknownColumn() *Column {
+	return &Column{DataType: "any"}
+}
+
+func boolColumn(notNull bool) *Column {
+	return &Column{DataType: "bool", NotNull: notNull}
+}
+
+func isUnknown(col *Column) bool {
+	return col.DataType == "any"
+}
+
+// The type and nullability of the value of an expression. Expressions which
+// can't be typed are of type any and nullable.
+//
+// Return an error if a column reference is ambiguous or doesn't exist
+func inferType(qc *QueryCatalog, res *ast.ResTarget, tables []*Table, node ast.Node) (*Column, error) {
+	switch n := node.(type) {
+
+	case *ast.A_Const:
+		switch n.Val.(type) {
+		case *ast.Integer:
+			return &Column{DataType: "integer", NotNull: true}, nil
+		case *ast.Float:
+			return &Column{DataType: "numeric", NotNull: true}, nil
+		case *ast.String:
+			return &Column{DataType: "text", NotNull: true}, nil
+		default:
+			return unknownColumn(), nil
+		}
+
+	case *ast.A_Expr:
+		return inferExprType(qc, res, tables, n)
+
+	case *ast.BoolExpr:
+		notNull := true
+		for _, arg := range n.Args.Items {
+			col, err := inferType(qc, res, tables, arg)
+			if err != nil {
+				return nil, err
+			}
+			notNull = notNull && col.NotNull
+		}
+		return boolColumn(notNull), nil
+
+	case *ast.BooleanTest, *ast.NullTest:
+		return boolColumn(true), nil
+
+	case *ast.CaseExpr:
+		results := []ast.Node{}
+		for _, item := range n.Args.Items {
+			if when, ok := item.(*ast.CaseWhen); ok {
+				results = append(results, when.Result)
+			}
+		}
+		if !isEmpty(n.Defresult) {
+			results = append(results, n.Defresult)
+		}
+		var typed *Column
+		notNull := !isEmpty(n.Defresult)
+		for _, result := range results {
+			col, err := inferType(qc, res, tables, result)
+			if err != nil {
+				return nil, err
+			}
+			if typed == nil && !isUnknown(col) {
+				typed = col
+			}
+			notNull = notNull && col.NotNull
+		}
+		if typed == nil {
+			return unknownColumn(), nil
+		}
+		col := *typed
+		col.NotNull = notNull
+		return &col, nil
+
+	case *ast.CoalesceExpr:
+		return inferFirstType(qc, res, tables, n.Args)
+
+	case *ast.MinMaxExpr:
+		return inferFirstType(qc, res, tables, n.Args)
+
+	case *ast.ColumnRef:
+		if hasStarRef(n) {
+			return unknownColumn(), nil
+		}
+		cols, err := outputColumnRefs(res, tables, n)
+		if err != nil {
+			return nil, err
+		}
+		return cols[0], nil
+
+	case *ast.FuncCall:
+		return inferFuncType(qc, res, tables, n)
+
+	case *ast.ParamRef:
+		return &Column{DataType: "any", NotNull: true}, nil
+
+	case *ast.SubLink:
+		switch n.SubLinkType {
+		case ast.EXISTS_SUBLINK:
+			return boolColumn(true), nil
+		case ast.EXPR_SUBLINK, ast.ARRAY_SUBLINK:
+			// Subqueries which reference the outer query can't be typed on
+			// their own
+			cols, err := outputColumns(qc, n.Subselect)
+			if err != nil || len(cols) == 0 {
+				return unknownColumn(), nil
+			}
+			col := *cols[0]
+			if n.SubLinkType == ast.ARRAY_SUBLINK {
+				col.IsArray = true
+				col.NotNull = true
+			} else {
+				// No row is a NULL
+				col.NotNull = false
+			}
+			return &col, nil
+		default:
+			return boolColumn(false), nil
+		}
+
+	case *ast.TypeCast:
+		if n.TypeName == nil {
+			return unknownColumn(), nil
+		}
+		col := toColumn(n.TypeName)
+		if c, ok := n.Arg.(*ast.A_Const); ok {
+			if _, ok := c.Val.(*ast.Null); ok {
+				col.NotNull = false
+				return col, nil
+			}
+		}
+		arg, err := inferType(qc, res, tables, n.Arg)
+		if err != nil {
+			return nil, err
+		}
+		if !isUnknown(arg) {
+			col.NotNull = arg.NotNull
+		}
+		return col, nil
+
+	default:
+		return unknownColumn(), nil
+
+	}
+}
+
+func inferExprType(qc *QueryCatalog, res *ast.ResTarget, tables []*Table, n *ast.A_Expr) (*Column, error) {
+	left := unknownColumn()
+	if n.Lexpr != nil {
+		col, err := inferType(qc, res, tables, n.Lexpr)
+		if err != nil {
+			return nil, err
+		}
+		left = col
+	}
+	right := unknownColumn()
+	if n.Rexpr != nil {
+		col, err := inferType(qc, res, tables, n.Rexpr)
+		if err != nil {
+			return nil, err
+		}
+		right = col
+	}
+	notNull := (n.Lexpr == nil || left.NotNull) && (n.Rexpr == nil || right.NotNull)
+
+	switch n.Kind {
+	case ast.AEXPR_DISTINCT, ast.AEXPR_NOT_DISTINCT:
+		return boolColumn(true), nil
+	case ast.AEXPR_NULLIF:
+		col := *left
+		col.NotNull = false
+		return &col, nil
+	case ast.AEXPR_PAREN:
+		return left, nil
+	case ast.AEXPR_OP:
+	default:
+		// The right-hand side of IN and BETWEEN is a list, whose nullability
+		// isn't known
+		if _, ok := n.Rexpr.(*ast.List); ok {
+			notNull = left.NotNull
+		}
+		return boolColumn(notNull), nil
+	}
+
+	op := astutils.Join(n.Name, "")
+	switch {
+	case lang.IsComparisonOperator(op), lang.IsBooleanOperator(op):
+		return boolColumn(notNull), nil
+
+	case op == "~" && n.Lexpr != nil:
+		// Regular expression match, rather than the bitwise NOT prefix
+		// operator
+		return boolColumn(notNull), nil
+
+	case lang.IsConcatOperator(op):
+		for _, col := range []*Column{left, right} {
+			if col.IsArray {
+				return &Column{DataType: col.DataType, IsArray: true, NotNull: notNull}, nil
+			}
+		}
+		return &Column{DataType: "text", NotNull: notNull}, nil
+
+	case lang.IsJSONTextOperator(op):
+		return &Column{DataType: "text"}, nil
+
+	case lang.IsJSONOperator(op):
+		if isUnknown(left) {
+			return unknownColumn(), nil
+		}
+		return &Column{DataType: left.DataType}, nil
+
+	case lang.IsMathematicalOperator(op):
+		if n.Lexpr == nil {
+			left = right
+		}
+		if n.Rexpr == nil {
+			right = left
+		}
+		typ := arithmeticType(op, left, right)
+		if typ == nil {
+			return unknownColumn(), nil
+		}
+		return &Column{DataType: typ.DataType, Type: typ.Type, NotNull: notNull}, nil
+
+	default:
+		return unknownColumn(), nil
+	}
+}
+
+// The operand whose type is the type of an arithmetic expression, or nil if
+// the type isn't known
+func arithmeticType(op string, left, right *Column) *Column {
+	if isUnknown(left) && isUnknown(right) {
+		return nil
+	}
+	if isUnknown(left) {
+		return right
+	}
+	if isUnknown(right) {
+		return left
+	}
+	lt, rt := canonicalType(left.DataType), canonicalType(right.DataType)
+	lrank, lnum := numericRanks[lt]
+	rrank, rnum := numericRanks[rt]
+	switch {
+	case lnum && rnum:
+		if rrank > lrank {
+			return right
+		}
+		return left
+	case op == "-" && lt == "date" && rt == "date":
+		return &Column{DataType: "integer"}
+	case op == "-" && lt == rt && strings.HasPrefix(lt, "timestamp"):
+		return &Column{DataType: "interval"}
+	case lnum:
+		// e.g. 2 * interval '1 day'
+		return right
+	default:
+		return left
+	}
+}
+
+// The type of the first argument with a known type. The result is not null
+// if any argument is not null, as for COALESCE, GREATEST and LEAST.
+func inferFirstType(qc *QueryCatalog, res *ast.ResTarget, tables []*Table, args *ast.List) (*Column, error) {
+	var typed *Column
+	var notNull bool
+	for _, arg := range args.Items {
+		col, err := inferType(qc, res, tables, arg)
+		if err != nil {
+			return nil, err
+		}
+		if typed == nil && !isUnknown(col) {
+			typed = col
+		}
+		notNull = notNull || col.NotNull
+	}
+	if typed == nil {
+		return &Column{DataType: "any", NotNull: notNull}, nil
+	}
+	col := *typed
+	col.NotNull = notNull
+	return &col, nil
+}
+
+func inferFuncType(qc *QueryCatalog, res *ast.ResTarget, tables []*Table, n *ast.FuncCall) (*Column, error) {
+	var args []*Column
+	if n.Args != nil {
+		for _, item := range n.Args.Items {
+			if narg, ok := item.(*ast.NamedArgExpr); ok {
+				item = narg.Arg
+			}
+			col, err := inferType(qc, res, tables, item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, col)
+		}
+	}
+	fun, err := resolveFuncCall(qc.catalog, n, args)
+	if err != nil || fun.ReturnType == nil {
+		return unknownColumn(), nil
+	}
+
+	col := &Column{DataType: dataType(fun.ReturnType), IsArray: isArray(fun.ReturnType)}
+	switch col.DataType {
+	case "anyelement", "anynonarray", "anycompatible":
+		if len(args) == 0 || isUnknown(args[0]) {
+			return unknownColumn(), nil
+		}
+		col.DataType = args[0].DataType
+	case "anyarray", "anycompatiblearray":
+		if len(args) == 0 || isUnknown(args[0]) {
+			return unknownColumn(), nil
+		}
+		col.DataType = args[0].DataType
+		col.IsArray = true
+	}
+
+	name := strings.ToLower(n.Func.Name)
+	if _, ok := nonNullFunctions[name]; ok || n.AggStar {
+		col.NotNull = true
+		return col, nil
+	}
+	if n.Over != nil {
+		// Window functions such as lag return NULL when there is no such row
+		// in the frame
+		_, ok := rankingFunctions[name]
+		col.NotNull = ok
+		return col, nil
+	}
+	if _, ok := nullableFunctions[name]; ok {
+		return col, nil
+	}
+	if _, ok := strictFunctions[name]; !ok {
+		return col, nil
+	}
+	col.NotNull = true
+	for _, arg := range args {
+		if !isUnknown(arg) && !arg.NotNull {
+			col.NotNull = false
+		}
+	}
+	return col, nil
+}
+
+// Prefer the overload of a function whose argument types match the types of
+// args, then a polymorphic overload, falling back to the first overload which
+// takes the right number of arguments.
+func resolveFuncCall(c *catalog.Catalog, call *ast.FuncCall, args []*Column) (*catalog.Function, error) {
+	funs, err := c.ListFuncsByName(call.Func)
+	if err == nil && len(funs) > 1 {
+		for _, polymorphic := range []bool{false, true} {
+			for i := range funs {
+				if funcArgsMatch(&funs[i], args, polymorphic) {
+					return &funs[i], nil
+				}
+			}
+		}
+	}
+	return c.ResolveFuncCall(call)
+}
+
+func funcArgsMatch(fun *catalog.Function, args []*Column, polymorphic bool) bool {
+	in := fun.InArgs()
+	if len(in) != len(args) {
+		return false
+	}
+	for i, arg := range in {
+		if arg.Type == nil || isUnknown(args[i]) {
+			continue
+		}
+		want := canonicalType(dataType(arg.Type))
+		if polymorphic && strings.HasPrefix(want, "any") {
+			continue
+		}
+		if want != canonicalType(args[i].DataType) || isArray(arg.Type) != args[i].IsArray {
+			return false
+		}
+	}
+	return true
+}
@@ -1,15 +1,46 @@
 package compiler
 
 import (
-	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/kyleconroy/sqlc/internal/sql/ast"
 	"github.com/kyleconroy/sqlc/internal/sql/astutils"
-	"github.com/kyleconroy/sqlc/internal/sql/lang"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
 	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
 )
 
+// OutputColumns computes the columns of the rows returned by a statement, such
+// as the SELECT of a view
+func (c *Compiler) OutputColumns(stmt ast.Node) ([]*catalog.Column, error) {
+	qc, err := buildQueryCatalog(c.catalog, stmt)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := outputColumns(qc, stmt)
+	if err != nil {
+		return nil, err
+	}
+	catCols := make([]*catalog.Column, 0, len(cols))
+	for i, col := range cols {
+		name := col.Name
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		typ := ast.TypeName{Name: col.DataType}
+		if i := strings.LastIndex(col.DataType, "."); i >= 0 {
+			typ = ast.TypeName{Schema: col.DataType[:i], Name: col.DataType[i+1:]}
+		}
+		catCols = append(catCols, &catalog.Column{
+			Name:      name,
+			Type:      typ,
+			IsNotNull: col.NotNull,
+			IsArray:   col.IsArray,
+		})
+	}
+	return catCols, nil
+}
+
 func hasStarRef(cf *ast.ColumnRef) bool {
 	for _, item := range cf.Fields.Items {
 		if _, ok := item.(*ast.A_Star); ok {
@@ -24,6 +55,9 @@ func hasStarRef(cf *ast.ColumnRef) bool {
 // Return an error if column references are ambiguous
 // Return an error if column references don't exist
 func outputColumns(qc *QueryCatalog, node ast.Node) ([]*Column, error) {
+	if n, ok := node.(*ast.SelectStmt); ok && isSetOperation(n) {
+		return setOperationColumns(qc, n)
+	}
 	tables, err := sourceTables(qc, node)
 	if err != nil {
 		return nil, err
@@ -54,69 +88,6 @@ func outputColumns(qc *QueryCatalog, node ast.Node) ([]*Column, error) {
 		}
 		switch n := res.Val.(type) {
 
-		case *ast.A_Expr:
-			name := ""
-			if res.Name != nil {
-				name = *res.Name
-			}
-			switch {
-			case lang.IsComparisonOperator(astutils.Join(n.Name, "")):
-				// TODO: Generate a name for these operations
-				cols = append(cols, &Column{Name: name, DataType: "bool", NotNull: true})
-			case lang.IsMathematicalOperator(astutils.Join(n.Name, "")):
-				// TODO: Generate correct numeric type
-				cols = append(cols, &Column{Name: name, DataType: "pg_catalog.int4", NotNull: true})
-			default:
-				cols = append(cols, &Column{Name: name, DataType: "any", NotNull: false})
-			}
-
-		case *ast.CaseExpr:
-			name := ""
-			if res.Name != nil {
-				name = *res.Name
-			}
-			// TODO: The TypeCase code has been copied from below. Instead, we need a recurse function to get the type of a node.
-			if tc, ok := n.Defresult.(*ast.TypeCast); ok {
-				if tc.TypeName == nil {
-					return nil, errors.New("no type name type cast")
-				}
-				name := ""
-				if ref, ok := tc.Arg.(*ast.ColumnRef); ok {
-					name = astutils.Join(ref.Fields, "_")
-				}
-				if res.Name != nil {
-					name = *res.Name
-				}
-				// TODO Validate column names
-				col := toColumn(tc.TypeName)
-				col.Name = name
-				cols = append(cols, col)
-			} else {
-				cols = append(cols, &Column{Name: name, DataType: "any", NotNull: false})
-			}
-
-		case *ast.CoalesceExpr:
-			var found bool
-			for _, arg := range n.Args.Items {
-				if found {
-					continue
-				}
-				if ref, ok := arg.(*ast.ColumnRef); ok {
-					columns, err := outputColumnRefs(res, tables, ref)
-					if err != nil {
-						return nil, err
-					}
-					for _, c := range columns {
-						found = true
-						c.NotNull = true
-						cols = append(cols, c)
-					}
-				}
-			}
-			if !found {
-				cols = append(cols, &Column{Name: "coalesce", DataType: "any", NotNull: false})
-			}
-
 		case *ast.ColumnRef:
 			if hasStarRef(n) {
 				// TODO: This code is copied in func expand()
@@ -150,59 +121,50 @@ func outputColumns(qc *QueryCatalog, node ast.Node) ([]*Column, error) {
 			}
 			cols = append(cols, columns...)
 
-		case *ast.FuncCall:
-			rel := n.Func
-			name := rel.Name
-			if res.Name != nil {
-				name = *res.Name
-			}
-			fun, err := qc.catalog.ResolveFuncCall(n)
-			if err == nil {
-				cols = append(cols, &Column{Name: name, DataType: dataType(fun.ReturnType), NotNull: true})
-			} else {
-				cols = append(cols, &Column{Name: name, DataType: "any"})
-			}
-
-		case *ast.SubLink:
-			name := "exists"
-			if res.Name != nil {
-				name = *res.Name
-			}
-			switch n.SubLinkType {
-			case ast.EXISTS_SUBLINK:
-				cols = append(cols, &Column{Name: name, DataType: "bool", NotNull: true})
-			default:
-				cols = append(cols, &Column{Name: name, DataType: "any", NotNull: false})
-			}
-
-		case *ast.TypeCast:
-			if n.TypeName == nil {
-				return nil, errors.New("no type name type cast")
-			}
-			name := ""
-			if ref, ok := n.Arg.(*ast.ColumnRef); ok {
-				name = astutils.Join(ref.Fields, "_")
+		default:
+			col, err := inferType(qc, res, tables, n)
+			if err != nil {
+				return nil, err
 			}
+			col.Name = outputColumnName(res, tables, n)
 			if res.Name != nil {
-				name = *res.Name
+				col.Name = *res.Name
 			}
-			// TODO Validate column names
-			col := toColumn(n.TypeName)
-			col.Name = name
 			cols = append(cols, col)
 
-		default:
-			name := ""
-			if res.Name != nil {
-				name = *res.Name
-			}
-			cols = append(cols, &Column{Name: name, DataType: "any", NotNull: false})
-
 		}
 	}
 	return cols, nil
 }
 
+// The name of an output column which isn't given an alias
+func outputColumnName(res *ast.ResTarget, tables []*Table, node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.CaseExpr:
+		if tc, ok := n.Defresult.(*ast.TypeCast); ok {
+			return outputColumnName(res, tables, tc)
+		}
+	case *ast.CoalesceExpr:
+		for _, arg := range n.Args.Items {
+			if ref, ok := arg.(*ast.ColumnRef); ok {
+				if cols, err := outputColumnRefs(res, tables, ref); err == nil {
+					return cols[0].Name
+				}
+			}
+		}
+		return "coalesce"
+	case *ast.FuncCall:
+		return n.Func.Name
+	case *ast.SubLink:
+		return "exists"
+	case *ast.TypeCast:
+		if ref, ok := n.Arg.(*ast.ColumnRef); ok {
+			return astutils.Join(ref.Fields, "_")
+		}
+	}
+	return ""
+}
+
 // Compute the output columns for a statement.
 //
 // Return an error if column references are ambiguous
@@ -211,6 +173,7 @@ func outputColumns(qc *QueryCatalog, node ast.Node) ([]*Column, error) {
 // Return an error if an unknown column is referenced
 func sourceTables(qc *QueryCatalog, node ast.Node) ([]*Table, error) {
 	var list *ast.List
+	var nullable map[ast.Node]bool
 	switch n := node.(type) {
 	case *ast.DeleteStmt:
 		list = &ast.List{
@@ -229,6 +192,8 @@ func sourceTables(qc *QueryCatalog, node ast.Node) ([]*Table, error) {
 				return false
 			}
 		})
+		nullable = map[ast.Node]bool{}
+		outerJoinRanges(n.FromClause, false, nullable)
 	case *ast.TruncateStmt:
 		list = astutils.Search(n.Relations, func(node ast.Node) bool {
 			_, ok := node.(*ast.RangeVar)
@@ -250,12 +215,16 @@ func sourceTables(qc *QueryCatalog, node ast.Node) ([]*Table, error) {
 			if err != nil {
 				return nil, err
 			}
-			tables = append(tables, &Table{
+			table := &Table{
 				Rel: &ast.TableName{
 					Name: *n.Alias.Aliasname,
 				},
 				Columns: cols,
-			})
+			}
+			if nullable[n] {
+				table = nullableTable(table)
+			}
+			tables = append(tables, table)
 
 		case *ast.RangeVar:
 			fqn, err := ParseTableName(n)
@@ -276,6 +245,9 @@ func sourceTables(qc *QueryCatalog, node ast.Node) ([]*Table, error) {
 					Name:    *n.Alias.Aliasname,
 				}
 			}
+			if nullable[n] {
+				table = nullableTable(table)
+			}
 			tables = append(tables, table)
 		default:
 			return nil, fmt.Errorf("sourceTable: unsupported list item type: %T", n)
@@ -284,6 +256,49 @@ func sourceTables(qc *QueryCatalog, node ast.Node) ([]*Table, error) {
 	return tables, nil
 }
 
+// Find the tables and subqueries in node which are on the nullable side of an
+// outer join, e.g. b in `a LEFT JOIN b`. A join nested on the nullable side
+// of another join is nullable as a whole.
+func outerJoinRanges(node ast.Node, nullable bool, ranges map[ast.Node]bool) {
+	switch n := node.(type) {
+	case *ast.List:
+		if n == nil {
+			return
+		}
+		for _, item := range n.Items {
+			outerJoinRanges(item, nullable, ranges)
+		}
+	case *ast.JoinExpr:
+		left, right := nullable, nullable
+		switch n.Jointype {
+		case ast.JOIN_LEFT:
+			right = true
+		case ast.JOIN_RIGHT:
+			left = true
+		case ast.JOIN_FULL:
+			left, right = true, true
+		}
+		outerJoinRanges(n.Larg, left, ranges)
+		outerJoinRanges(n.Rarg, right, ranges)
+	case *ast.RangeVar, *ast.RangeSubselect:
+		if nullable {
+			ranges[n] = true
+		}
+	}
+}
+
+// The columns of a table on the nullable side of an outer join are NULL for
+// rows without a match, whatever their declared type
+func nullableTable(t *Table) *Table {
+	cols := make([]*Column, len(t.Columns))
+	for i, c := range t.Columns {
+		col := *c
+		col.NotNull = false
+		cols[i] = &col
+	}
+	return &Table{Rel: t.Rel, Columns: cols}
+}
+
 func outputColumnRefs(res *ast.ResTarget, tables []*Table, node *ast.ColumnRef) ([]*Column, error) {
 	parts := stringSlice(node.Fields)
 	var name, alias string
@@ -324,14 +339,14 @@ func outputColumnRefs(res *ast.ResTarget, tables []*Table, node *ast.ColumnRef)
 		return nil, &sqlerr.Error{
 			Code:     "42703",
 			Message:  fmt.Sprintf("column \"%s\" does not exist", name),
-			Location: res.Location,
+			Location: node.Location,
 		}
 	}
 	if found > 1 {
 		return nil, &sqlerr.Error{
 			Code:     "42703",
 			Message:  fmt.Sprintf("column reference \"%s\" is ambiguous", name),
-			Location: res.Location,
+			Location: node.Location,
 		}
 	}
 	return cols, nil
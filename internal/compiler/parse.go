@@ -44,13 +44,10 @@ func (c *Compiler) parseQuery(stmt ast.Node, src string, o opts.Parser) (*Query,
 	if !ok {
 		return nil, errors.New("node is not a statement")
 	}
-	switch n := raw.Stmt.(type) {
+	switch raw.Stmt.(type) {
 	case *ast.SelectStmt:
 	case *ast.DeleteStmt:
 	case *ast.InsertStmt:
-		if err := validate.InsertStmt(n); err != nil {
-			return nil, err
-		}
 	case *ast.TruncateStmt:
 	case *ast.UpdateStmt:
 	default:
@@ -71,14 +68,25 @@ func (c *Compiler) parseQuery(stmt ast.Node, src string, o opts.Parser) (*Query,
 	if err != nil {
 		return nil, err
 	}
+
+	raw, namedParams, edits := rewrite.NamedParameters(c.conf.Engine, raw)
 	if err := validate.Cmd(raw.Stmt, name, cmd); err != nil {
 		return nil, err
 	}
-
-	raw, namedParams, edits := rewrite.NamedParameters(c.conf.Engine, raw)
+	// Checked after the command, so that the errors specific to a :copyfrom
+	// query are reported first
+	if n, ok := raw.Stmt.(*ast.InsertStmt); ok {
+		if err := validate.InsertStmt(n); err != nil {
+			return nil, err
+		}
+	}
 	rvs := rangeVars(raw.Stmt)
 	refs := findParameters(raw.Stmt)
 	if o.UsePositionalParameters {
+		// Positional parameters are bound in the order they appear in the
+		// query, which isn't always the order they are found in, e.g. the
+		// LIMIT of a UNION is found before the parameters of its arms
+		sort.SliceStable(refs, func(i, j int) bool { return refs[i].ref.Location < refs[j].ref.Location })
 		edits, err = rewriteNumberedParameters(refs, raw, rawSQL)
 		if err != nil {
 			return nil, err
@@ -87,15 +95,29 @@ func (c *Compiler) parseQuery(stmt ast.Node, src string, o opts.Parser) (*Query,
 		refs = uniqueParamRefs(refs)
 		sort.Slice(refs, func(i, j int) bool { return refs[i].ref.Number < refs[j].ref.Number })
 	}
-	params, err := resolveCatalogRefs(c.catalog, rvs, refs, namedParams)
+	qc, err := buildQueryCatalog(c.catalog, raw.Stmt)
 	if err != nil {
 		return nil, err
 	}
-
-	qc, err := buildQueryCatalog(c.catalog, raw.Stmt)
+	var params []Parameter
+	if sel, ok := raw.Stmt.(*ast.SelectStmt); ok && isSetOperation(sel) {
+		params, err = resolveSetOperationRefs(qc, sel, rvs, refs, namedParams)
+	} else {
+		params, err = resolveCatalogRefs(qc, rvs, refs, namedParams)
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	var insertTable *ast.TableName
+	var insertColumns []string
+	if cmd == metadata.CmdCopyFrom {
+		insertTable, insertColumns, err = copyFromTarget(raw.Stmt.(*ast.InsertStmt), params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	cols, err := outputColumns(qc, raw.Stmt)
 	if err != nil {
 		return nil, err
@@ -125,15 +147,45 @@ func (c *Compiler) parseQuery(stmt ast.Node, src string, o opts.Parser) (*Query,
 	}
 
 	return &Query{
-		Cmd:      cmd,
-		Comments: comments,
-		Name:     name,
-		Params:   params,
-		Columns:  cols,
-		SQL:      trimmed,
+		Cmd:               cmd,
+		Comments:          comments,
+		Name:              name,
+		Params:            params,
+		Columns:           cols,
+		SQL:               trimmed,
+		InsertIntoTable:   insertTable,
+		InsertIntoColumns: insertColumns,
 	}, nil
 }
 
+// The table a :copyfrom query inserts into, and the column each of its
+// parameters is inserted into
+func copyFromTarget(stmt *ast.InsertStmt, params []Parameter) (*ast.TableName, []string, error) {
+	table, err := ParseTableName(stmt.Relation)
+	if err != nil {
+		return nil, nil, err
+	}
+	// validate.Cmd has checked that the statement has a single VALUES list
+	// of parameters
+	values := stmt.SelectStmt.(*ast.SelectStmt).ValuesLists.Items[0].(*ast.List)
+	if len(values.Items) != len(stmt.Cols.Items) {
+		return nil, nil, fmt.Errorf("INSERT has %d target columns and %d expressions", len(stmt.Cols.Items), len(values.Items))
+	}
+	names := map[int]string{}
+	for i, item := range values.Items {
+		res, ok := stmt.Cols.Items[i].(*ast.ResTarget)
+		if !ok || res.Name == nil {
+			return nil, nil, fmt.Errorf("unexpected INSERT target %T", stmt.Cols.Items[i])
+		}
+		names[item.(*ast.ParamRef).Number] = *res.Name
+	}
+	columns := make([]string, len(params))
+	for i, p := range params {
+		columns[i] = names[p.Number]
+	}
+	return table, columns, nil
+}
+
 func rangeVars(root ast.Node) []*ast.RangeVar {
 	var vars []*ast.RangeVar
 	find := astutils.VisitorFunc(func(node ast.Node) {
@@ -28,6 +28,11 @@ type Query struct {
 	Params   []Parameter
 	Comments []string
 
+	// The table and columns a :copyfrom query inserts into, in the order
+	// of Params
+	InsertIntoTable   *ast.TableName
+	InsertIntoColumns []string
+
 	// XXX: Hack
 	Filename string
 }
@@ -0,0 +1,171 @@
+package compiler
+
+import (
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/opts"
+	"github.com/kyleconroy/sqlc/internal/source"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/astutils"
+	"github.com/kyleconroy/sqlc/internal/sql/rewrite"
+)
+
+// A Reference links a table, column or parameter used in a query file to the
+// object it refers to
+type Reference struct {
+	Location int
+	Length   int
+	Query    *Query
+
+	// The referenced table, or the table of the referenced column
+	Table *ast.TableName
+	// Set for column references and for parameters whose type comes from a
+	// column
+	Column *Column
+	// Set for parameters
+	Param *Parameter
+}
+
+// References compiles the queries in src and returns the references they
+// contain. The file doesn't have to be one of the configured query files.
+// Queries which fail to compile are skipped.
+func (c *Compiler) References(src string, o opts.Parser) []Reference {
+	stmts, err := c.parser.Parse(strings.NewReader(src))
+	if err != nil {
+		return nil
+	}
+	// parseQuery rewrites named parameters in place, so keep an untouched
+	// copy of each statement to find where the parameters were written
+	untouched, err := c.parser.Parse(strings.NewReader(src))
+	if err != nil || len(untouched) != len(stmts) {
+		return nil
+	}
+	var refs []Reference
+	for i, stmt := range stmts {
+		query, err := c.parseQuery(stmt.Raw, src, o)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, c.queryReferences(src, untouched[i].Raw, query)...)
+	}
+	return refs
+}
+
+func (c *Compiler) queryReferences(src string, raw *ast.RawStmt, query *Query) []Reference {
+	var refs []Reference
+
+	raw, _, edits := rewrite.NamedParameters(c.conf.Engine, raw)
+	lengths := map[int]int{}
+	for _, edit := range edits {
+		lengths[raw.StmtLocation+edit.Location] = len(edit.Old)
+	}
+	for _, p := range findParameters(raw.Stmt) {
+		ref := Reference{Location: p.ref.Location, Query: query}
+		if n, ok := lengths[p.ref.Location]; ok {
+			ref.Length = n
+		} else {
+			start, end := source.TokenSpan(src, p.ref.Location)
+			ref.Location, ref.Length = start, end-start
+		}
+		for i := range query.Params {
+			if query.Params[i].Number == p.ref.Number {
+				ref.Param = &query.Params[i]
+				ref.Column = ref.Param.Column
+				if ref.Column != nil {
+					ref.Table = ref.Column.Table
+				}
+				break
+			}
+		}
+		if ref.Param != nil {
+			refs = append(refs, ref)
+		}
+	}
+
+	for _, rv := range rangeVars(raw.Stmt) {
+		if rv.Relname == nil {
+			continue
+		}
+		fqn, err := ParseTableName(rv)
+		if err != nil {
+			continue
+		}
+		if _, err := c.catalog.GetTable(fqn); err != nil {
+			continue
+		}
+		start, end := source.TokenSpan(src, rv.Location)
+		refs = append(refs, Reference{
+			Location: start,
+			Length:   end - start,
+			Query:    query,
+			Table:    fqn,
+		})
+	}
+
+	qc, err := buildQueryCatalog(c.catalog, raw.Stmt)
+	if err != nil {
+		return refs
+	}
+	tables, err := sourceTables(qc, raw.Stmt)
+	if err != nil {
+		return refs
+	}
+	found := astutils.Search(raw.Stmt, func(n ast.Node) bool {
+		_, ok := n.(*ast.ColumnRef)
+		return ok
+	})
+	for _, item := range found.Items {
+		ref := item.(*ast.ColumnRef)
+		if hasStarRef(ref) {
+			continue
+		}
+		col := resolveColumnRef(tables, ref)
+		if col == nil {
+			continue
+		}
+		start, end := source.TokenSpan(src, ref.Location)
+		refs = append(refs, Reference{
+			Location: start,
+			Length:   end - start,
+			Query:    query,
+			Table:    col.Table,
+			Column:   col,
+		})
+	}
+	return refs
+}
+
+// Find the column a reference refers to, or nil if the reference is
+// ambiguous or doesn't exist
+func resolveColumnRef(tables []*Table, ref *ast.ColumnRef) *Column {
+	parts := stringSlice(ref.Fields)
+	var name, alias string
+	switch len(parts) {
+	case 1:
+		name = parts[0]
+	case 2:
+		alias = parts[0]
+		name = parts[1]
+	case 3:
+		alias = parts[1]
+		name = parts[2]
+	default:
+		return nil
+	}
+	var found *Column
+	for _, t := range tables {
+		if alias != "" && t.Rel.Name != alias {
+			continue
+		}
+		for _, col := range t.Columns {
+			if col.Name != name {
+				continue
+			}
+			if found != nil {
+				return nil
+			}
+			found = col
+		}
+	}
+	return found
+}
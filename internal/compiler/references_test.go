@@ -0,0 +1,100 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/opts"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+)
+
+const refSchema = `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL
+);
+
+ALTER TABLE authors ADD COLUMN bio text;
+`
+
+const refQueries = `-- name: GetAuthor :one
+SELECT name, bio FROM authors a WHERE a.id = $1;
+
+-- name: UpdateBio :exec
+UPDATE authors SET bio = sqlc.arg(bio) WHERE name = sqlc.arg(name);
+`
+
+func TestReferences(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-references")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	schema := filepath.Join(dir, "schema.sql")
+	// The overlay replaces the contents of the file on disk
+	if err := ioutil.WriteFile(schema, []byte("CREATE TABLE authors (id BIGSERIAL);"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	conf := config.SQL{
+		Engine: config.EnginePostgreSQL,
+		Schema: []string{schema},
+	}
+	c := NewCompiler(conf, config.CombinedSettings{})
+	c.Overlay(map[string]string{schema: refSchema})
+	if err := c.ParseCatalog(conf.Schema); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, ref := range c.References(refQueries, opts.Parser{}) {
+		text := refQueries[ref.Location : ref.Location+ref.Length]
+		switch {
+		case ref.Param != nil:
+			got = append(got, fmt.Sprintf("%s: param %d %s %t", text, ref.Param.Number, ref.Param.Column.DataType, ref.Param.Column.NotNull))
+		case ref.Column != nil:
+			got = append(got, fmt.Sprintf("%s: column %s.%s %s %t", text, ref.Table.Name, ref.Column.Name, ref.Column.DataType, ref.Column.NotNull))
+		default:
+			got = append(got, fmt.Sprintf("%s: table %s", text, ref.Table.Name))
+		}
+	}
+	want := []string{
+		"$1: param 1 bigserial true",
+		"authors: table authors",
+		"name: column authors.name text true",
+		"bio: column authors.bio text false",
+		"a.id: column authors.id bigserial true",
+		"sqlc.arg(bio): param 1 text false",
+		"sqlc.arg(name): param 2 text true",
+		"authors: table authors",
+		"name: column authors.name text true",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+
+	for _, test := range []struct {
+		column string
+		text   string
+	}{
+		{"", "authors"},
+		{"id", "id"},
+		{"bio", "bio"},
+	} {
+		span, ok := c.Definition(&ast.TableName{Name: "authors"}, test.column)
+		if !ok {
+			t.Fatalf("no definition for %q", test.column)
+		}
+		if span.Filename != schema {
+			t.Errorf("expected %s; got %s", schema, span.Filename)
+		}
+		if text := refSchema[span.Location : span.Location+span.Length]; text != test.text {
+			t.Errorf("expected %q; got %q", test.text, text)
+		}
+	}
+}
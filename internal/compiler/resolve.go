@@ -2,10 +2,8 @@ package compiler
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/kyleconroy/sqlc/internal/sql/ast"
-	"github.com/kyleconroy/sqlc/internal/sql/astutils"
 	"github.com/kyleconroy/sqlc/internal/sql/catalog"
 	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
 )
@@ -18,7 +16,8 @@ func dataType(n *ast.TypeName) string {
 	}
 }
 
-func resolveCatalogRefs(c *catalog.Catalog, rvs []*ast.RangeVar, args []paramRef, names map[int]string) ([]Parameter, error) {
+func resolveCatalogRefs(qc *QueryCatalog, rvs []*ast.RangeVar, args []paramRef, names map[int]string) ([]Parameter, error) {
+	c := qc.catalog
 	aliasMap := map[string]*ast.TableName{}
 	// TODO: Deprecate defaultTable
 	var defaultTable *ast.TableName
@@ -65,6 +64,8 @@ func resolveCatalogRefs(c *catalog.Catalog, rvs []*ast.RangeVar, args []paramRef
 		}
 	}
 
+	exprTables := paramTables(qc, rvs)
+
 	var a []Parameter
 	for _, ref := range args {
 		switch n := ref.parent.(type) {
@@ -90,86 +91,40 @@ func resolveCatalogRefs(c *catalog.Catalog, rvs []*ast.RangeVar, args []paramRef
 			})
 
 		case *ast.A_Expr:
-			// TODO: While this works for a wide range of simple expressions,
-			// more complicated expressions will cause this logic to fail.
-			list := astutils.Search(n.Lexpr, func(node ast.Node) bool {
-				_, ok := node.(*ast.ColumnRef)
-				return ok
+			col, err := inferExprParam(qc, exprTables, n, ref.ref)
+			if err != nil {
+				return nil, err
+			}
+			col.Name = parameterName(ref.ref.Number, col.Name)
+			a = append(a, Parameter{
+				Number: ref.ref.Number,
+				Column: col,
 			})
 
-			if len(list.Items) == 0 {
-				return nil, &sqlerr.Error{
-					Code:     "XXXXX",
-					Message:  "no column reference found",
-					Location: n.Location,
-				}
+		case *ast.BoolExpr, *ast.CaseExpr, *ast.CoalesceExpr, *ast.MinMaxExpr, *ast.SubLink:
+			col, err := inferParamType(qc, exprTables, n, ref.ref)
+			if err != nil {
+				return nil, err
 			}
+			col.Name = parameterName(ref.ref.Number, col.Name)
+			a = append(a, Parameter{
+				Number: ref.ref.Number,
+				Column: col,
+			})
 
-			switch left := list.Items[0].(type) {
-			case *ast.ColumnRef:
-				items := stringSlice(left.Fields)
-				var key, alias string
-				switch len(items) {
-				case 1:
-					key = items[0]
-				case 2:
-					alias = items[0]
-					key = items[1]
-				default:
-					panic("too many field items: " + strconv.Itoa(len(items)))
-				}
-
-				search := tables
-				if alias != "" {
-					if original, ok := aliasMap[alias]; ok {
-						search = []*ast.TableName{original}
-					} else {
-						for _, fqn := range tables {
-							if fqn.Name == alias {
-								search = []*ast.TableName{fqn}
-							}
-						}
-					}
-				}
-
-				var found int
-				for _, table := range search {
-					if c, ok := typeMap[table.Schema][table.Name][key]; ok {
-						found += 1
-						if ref.name != "" {
-							key = ref.name
-						}
-						a = append(a, Parameter{
-							Number: ref.ref.Number,
-							Column: &Column{
-								Name:     parameterName(ref.ref.Number, key),
-								DataType: dataType(&c.Type),
-								NotNull:  c.IsNotNull,
-								IsArray:  c.IsArray,
-								Table:    table,
-							},
-						})
-					}
-				}
-
-				if found == 0 {
-					return nil, &sqlerr.Error{
-						Code:     "42703",
-						Message:  fmt.Sprintf("column \"%s\" does not exist", key),
-						Location: left.Location,
-					}
+		case *ast.FuncCall:
+			var argTypes []*Column
+			for _, item := range n.Args.Items {
+				if narg, ok := item.(*ast.NamedArgExpr); ok {
+					item = narg.Arg
 				}
-				if found > 1 {
-					return nil, &sqlerr.Error{
-						Code:     "42703",
-						Message:  fmt.Sprintf("column reference \"%s\" is ambiguous", key),
-						Location: left.Location,
-					}
+				col, err := inferType(qc, &ast.ResTarget{}, exprTables, item)
+				if err != nil {
+					col = unknownColumn()
 				}
+				argTypes = append(argTypes, col)
 			}
-
-		case *ast.FuncCall:
-			fun, err := c.ResolveFuncCall(n)
+			fun, err := resolveFuncCall(c, n, argTypes)
 			if err != nil {
 				// Synthesize a function on the fly to avoid returning with an error
 				// for an unknown Postgres function (e.g. defined in an extension)
@@ -251,13 +206,24 @@ func resolveCatalogRefs(c *catalog.Catalog, rvs []*ast.RangeVar, args []paramRef
 					paramName = funcName
 				}
 
+				col := &Column{
+					DataType: dataType(paramType),
+					NotNull:  true,
+					IsArray:  isArray(paramType),
+				}
+				if isPolymorphic(col.DataType) && len(fun.Args) == len(argTypes) {
+					var declared []string
+					for _, arg := range fun.Args {
+						declared = append(declared, dataType(arg.Type))
+					}
+					if typ := polymorphicArgType(col.DataType, declared, argTypes); typ != nil {
+						col = typ
+					}
+				}
+				col.Name = parameterName(ref.ref.Number, paramName)
 				a = append(a, Parameter{
 					Number: ref.ref.Number,
-					Column: &Column{
-						Name:     parameterName(ref.ref.Number, paramName),
-						DataType: dataType(paramType),
-						NotNull:  true,
-					},
+					Column: col,
 				})
 			}
 
@@ -0,0 +1,222 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/astutils"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
+)
+
+// A set operation such as `SELECT ... UNION SELECT ...` is a SelectStmt
+// without a target list, which combines the rows of its Larg and Rarg
+func isSetOperation(n *ast.SelectStmt) bool {
+	return n.Larg != nil && n.Rarg != nil
+}
+
+func setOperationName(n *ast.SelectStmt) string {
+	switch n.Op {
+	case ast.SETOP_INTERSECT:
+		return "INTERSECT"
+	case ast.SETOP_EXCEPT:
+		return "EXCEPT"
+	default:
+		return "UNION"
+	}
+}
+
+// The SELECT statements combined by a set operation, from left to right
+func setOperationArms(n *ast.SelectStmt) []*ast.SelectStmt {
+	if !isSetOperation(n) {
+		return []*ast.SelectStmt{n}
+	}
+	return append(setOperationArms(n.Larg), setOperationArms(n.Rarg)...)
+}
+
+// The i-th item of the target list of a set operation arm. The columns of a
+// set operation are those of its leftmost SELECT statement.
+func setOperationTarget(n *ast.SelectStmt, i int) *ast.ResTarget {
+	for isSetOperation(n) {
+		n = n.Larg
+	}
+	if n.TargetList == nil || i >= len(n.TargetList.Items) {
+		return nil
+	}
+	res, _ := n.TargetList.Items[i].(*ast.ResTarget)
+	return res
+}
+
+func setOperationLocation(n *ast.SelectStmt, i int) int {
+	if res := setOperationTarget(n, i); res != nil {
+		return res.Location
+	}
+	return 0
+}
+
+// A string literal such as 'open' has no type of its own until it is
+// combined with another value, so it can be an enum value or a timestamp.
+// Only the literals of a SELECT statement are untyped: the columns of a
+// nested set operation have already been resolved.
+func isUntypedLiteral(n *ast.SelectStmt, i int) bool {
+	if isSetOperation(n) {
+		return false
+	}
+	res := setOperationTarget(n, i)
+	if res == nil {
+		return false
+	}
+	con, ok := res.Val.(*ast.A_Const)
+	if !ok {
+		return false
+	}
+	_, ok = con.Val.(*ast.String)
+	return ok
+}
+
+func untyped(col *Column) *Column {
+	c := *col
+	c.DataType = "any"
+	c.Type = nil
+	c.IsArray = false
+	return &c
+}
+
+// The output columns of a set operation are named after the columns of its
+// left arm. Their types are unified across both arms, and they are nullable
+// if they are nullable in either arm.
+func setOperationColumns(qc *QueryCatalog, n *ast.SelectStmt) ([]*Column, error) {
+	left, err := outputColumns(qc, n.Larg)
+	if err != nil {
+		return nil, err
+	}
+	right, err := outputColumns(qc, n.Rarg)
+	if err != nil {
+		return nil, err
+	}
+	op := setOperationName(n)
+	if len(left) != len(right) {
+		return nil, &sqlerr.Error{
+			Code:     "42601",
+			Message:  fmt.Sprintf("each %s query must have the same number of columns", op),
+			Location: setOperationLocation(n.Rarg, 0),
+		}
+	}
+	cols := make([]*Column, len(left))
+	for i := range left {
+		l, r := left[i], right[i]
+		lu, ru := isUntypedLiteral(n.Larg, i), isUntypedLiteral(n.Rarg, i)
+		switch {
+		case lu && !ru:
+			l = untyped(l)
+		case ru && !lu:
+			r = untyped(r)
+		}
+		col, err := setOperationType(op, l, r, setOperationLocation(n.Rarg, i))
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// The order in which date and time types are promoted when they are combined
+var dateTimeRanks = map[string]int{
+	"date":                        1,
+	"timestamp without time zone": 2,
+	"timestamp with time zone":    3,
+}
+
+var timeRanks = map[string]int{
+	"time without time zone": 1,
+	"time with time zone":    2,
+}
+
+// The type of a column combining the values of left and right, following
+// PostgreSQL's resolution of UNION types: an untyped value, such as NULL or
+// a string literal, takes the type of the other arm, a number or a date is
+// promoted to the wider type of the two, and strings of different kinds
+// become text
+func setOperationType(op string, left, right *Column, loc int) (*Column, error) {
+	col := *left
+	col.NotNull = left.NotNull && right.NotNull
+	if left.Table == nil || right.Table == nil || *left.Table != *right.Table {
+		col.Table = nil
+	}
+
+	lt, rt := canonicalType(left.DataType), canonicalType(right.DataType)
+	switch {
+	case isUnknown(right):
+		return &col, nil
+	case isUnknown(left):
+		col.DataType = right.DataType
+		col.Type = right.Type
+		col.IsArray = right.IsArray
+		return &col, nil
+	case left.IsArray != right.IsArray:
+	case lt == rt:
+		if lt == "text" && left.DataType != right.DataType {
+			col.DataType = "text"
+			col.Type = nil
+		}
+		return &col, nil
+	default:
+		for _, ranks := range []map[string]int{numericRanks, dateTimeRanks, timeRanks} {
+			lrank, lok := ranks[lt]
+			rrank, rok := ranks[rt]
+			if !lok || !rok {
+				continue
+			}
+			if rrank > lrank {
+				col.DataType = right.DataType
+				col.Type = right.Type
+			}
+			return &col, nil
+		}
+	}
+
+	ltype, rtype := lt, rt
+	if left.IsArray {
+		ltype += "[]"
+	}
+	if right.IsArray {
+		rtype += "[]"
+	}
+	return nil, &sqlerr.Error{
+		Code:     "42804",
+		Message:  fmt.Sprintf("%s types %s and %s cannot be matched", op, ltype, rtype),
+		Location: loc,
+	}
+}
+
+// The arms of a set operation are separate queries, so each parameter is
+// resolved against the tables of the arm it appears in. Parameters outside
+// of the arms, such as those of the ORDER BY and LIMIT clauses, are resolved
+// against every table in the statement.
+func resolveSetOperationRefs(qc *QueryCatalog, n *ast.SelectStmt, rvs []*ast.RangeVar, args []paramRef, names map[int]string) ([]Parameter, error) {
+	armRvs := map[*ast.ParamRef][]*ast.RangeVar{}
+	for _, arm := range setOperationArms(n) {
+		vars := rangeVars(arm)
+		list := astutils.Search(arm, func(node ast.Node) bool {
+			_, ok := node.(*ast.ParamRef)
+			return ok
+		})
+		for _, item := range list.Items {
+			armRvs[item.(*ast.ParamRef)] = vars
+		}
+	}
+
+	var params []Parameter
+	for _, arg := range args {
+		vars, ok := armRvs[arg.ref]
+		if !ok {
+			vars = rvs
+		}
+		resolved, err := resolveCatalogRefs(qc, vars, []paramRef{arg}, names)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, resolved...)
+	}
+	return params, nil
+}
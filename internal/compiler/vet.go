@@ -0,0 +1,97 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/opts"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
+)
+
+// A Rule checks a compiled query for problems that aren't errors, but are
+// likely to be mistakes.
+//
+// Each problem is returned as an error. Return a *sqlerr.Error with a Location
+// to point at a specific part of the query; otherwise the problem is reported
+// at the start of the statement.
+type Rule interface {
+	Name() string
+	Check(c *catalog.Catalog, raw *ast.RawStmt, q *Query) []error
+}
+
+var builtinRules = []Rule{
+	missingWhere{},
+	oneWithoutLimit{},
+	selectStarMany{},
+	unusedParams{},
+}
+
+// Rules returns the built-in rules enabled by the package configuration
+func Rules(conf config.SQLVet) ([]Rule, error) {
+	known := map[string]Rule{}
+	for _, r := range builtinRules {
+		known[r.Name()] = r
+	}
+	for _, name := range append(conf.Enable, conf.Disable...) {
+		if _, ok := known[name]; !ok {
+			var names []string
+			for n := range known {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown vet rule %q, expected one of: %s", name, strings.Join(names, ", "))
+		}
+	}
+	enabled := map[string]bool{}
+	for _, r := range builtinRules {
+		enabled[r.Name()] = len(conf.Enable) == 0
+	}
+	for _, name := range conf.Enable {
+		enabled[name] = true
+	}
+	for _, name := range conf.Disable {
+		enabled[name] = false
+	}
+	var rules []Rule
+	for _, r := range builtinRules {
+		if enabled[r.Name()] {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// Vet compiles every query and runs the rules against each one. Both
+// compilation errors and rule violations are returned as a *multierr.Error.
+func (c *Compiler) Vet(rules []Rule, o opts.Parser) error {
+	_, err := c.parseQueries(o, rules)
+	return err
+}
+
+func violation(r Rule, loc int, format string, args ...interface{}) error {
+	return &sqlerr.Error{
+		Code:     r.Name(),
+		Message:  fmt.Sprintf("%s: %s", r.Name(), fmt.Sprintf(format, args...)),
+		Location: loc,
+	}
+}
+
+// IsViolation reports whether err is a problem found by a rule, rather than
+// an error compiling the query
+func IsViolation(err error) bool {
+	var serr *sqlerr.Error
+	if !errors.As(err, &serr) {
+		return false
+	}
+	for _, r := range builtinRules {
+		if serr.Code == r.Name() {
+			return true
+		}
+	}
+	return false
+}
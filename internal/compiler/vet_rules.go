@@ -0,0 +1,311 @@
+package compiler
+
+import (
+	"github.com/kyleconroy/sqlc/internal/metadata"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/astutils"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
+)
+
+// UPDATE and DELETE statements without a WHERE clause modify every row in the
+// table, which is rarely intended
+type missingWhere struct{}
+
+func (missingWhere) Name() string {
+	return "missing-where"
+}
+
+func (r missingWhere) Check(c *catalog.Catalog, raw *ast.RawStmt, q *Query) []error {
+	switch n := raw.Stmt.(type) {
+	case *ast.DeleteStmt:
+		if isEmpty(n.WhereClause) {
+			return []error{violation(r, raw.Pos(), "DELETE without a WHERE clause removes every row")}
+		}
+	case *ast.UpdateStmt:
+		if isEmpty(n.WhereClause) {
+			return []error{violation(r, raw.Pos(), "UPDATE without a WHERE clause modifies every row")}
+		}
+	}
+	return nil
+}
+
+// The PostgreSQL engine converts missing clauses to *ast.TODO instead of nil
+func isEmpty(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	_, ok := node.(*ast.TODO)
+	return ok
+}
+
+// A :one query only returns the first row. If the SELECT can match more than
+// one row, the result depends on the order rows are returned in.
+type oneWithoutLimit struct{}
+
+func (oneWithoutLimit) Name() string {
+	return "one-without-limit"
+}
+
+func (r oneWithoutLimit) Check(c *catalog.Catalog, raw *ast.RawStmt, q *Query) []error {
+	if q.Cmd != metadata.CmdOne {
+		return nil
+	}
+	n, ok := raw.Stmt.(*ast.SelectStmt)
+	if !ok {
+		return nil
+	}
+	if !isEmpty(n.LimitCount) {
+		return nil
+	}
+	if n.FromClause == nil || len(n.FromClause.Items) == 0 {
+		return nil
+	}
+	if isAggregate(n) {
+		return nil
+	}
+	if hasUniqueFilter(c, n) {
+		return nil
+	}
+	return []error{violation(r, raw.Pos(), "query %q may return more than one row; add a LIMIT or filter on a unique column", q.Name)}
+}
+
+var aggregateFuncs = map[string]struct{}{
+	"array_agg":  {},
+	"avg":        {},
+	"bit_and":    {},
+	"bit_or":     {},
+	"bool_and":   {},
+	"bool_or":    {},
+	"count":      {},
+	"every":      {},
+	"json_agg":   {},
+	"jsonb_agg":  {},
+	"max":        {},
+	"min":        {},
+	"string_agg": {},
+	"sum":        {},
+}
+
+// A SELECT without a GROUP BY that calls an aggregate function returns
+// exactly one row
+func isAggregate(n *ast.SelectStmt) bool {
+	if n.GroupClause != nil && len(n.GroupClause.Items) > 0 {
+		return false
+	}
+	for _, item := range n.TargetList.Items {
+		res, ok := item.(*ast.ResTarget)
+		if !ok {
+			continue
+		}
+		fn, ok := res.Val.(*ast.FuncCall)
+		if !ok || fn.Func == nil {
+			continue
+		}
+		if _, ok := aggregateFuncs[fn.Func.Name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Report if the WHERE clause requires a unique column to equal a value
+func hasUniqueFilter(c *catalog.Catalog, n *ast.SelectStmt) bool {
+	tables := map[string]*catalog.Table{}
+	var all []*catalog.Table
+	for _, item := range rangeVars(n.FromClause) {
+		fqn, err := ParseTableName(item)
+		if err != nil {
+			continue
+		}
+		table, err := c.GetTable(fqn)
+		if err != nil {
+			continue
+		}
+		all = append(all, &table)
+		tables[fqn.Name] = &table
+		if item.Alias != nil && item.Alias.Aliasname != nil {
+			tables[*item.Alias.Aliasname] = &table
+		}
+	}
+
+	for _, expr := range conjuncts(n.WhereClause) {
+		eq, ok := expr.(*ast.A_Expr)
+		if !ok || astutils.Join(eq.Name, "") != "=" {
+			continue
+		}
+		for _, side := range []ast.Node{eq.Lexpr, eq.Rexpr} {
+			ref, ok := side.(*ast.ColumnRef)
+			if !ok {
+				continue
+			}
+			parts := stringSlice(ref.Fields)
+			search := all
+			var name string
+			switch len(parts) {
+			case 1:
+				name = parts[0]
+			case 2:
+				t, ok := tables[parts[0]]
+				if !ok {
+					continue
+				}
+				search = []*catalog.Table{t}
+				name = parts[1]
+			default:
+				continue
+			}
+			for _, t := range search {
+				for _, col := range t.Columns {
+					if col.Name == name && col.IsUnique {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Split an expression into the terms joined by AND
+func conjuncts(node ast.Node) []ast.Node {
+	if node == nil {
+		return nil
+	}
+	b, ok := node.(*ast.BoolExpr)
+	if !ok || b.Boolop != ast.AND_EXPR {
+		return []ast.Node{node}
+	}
+	var out []ast.Node
+	for _, arg := range b.Args.Items {
+		out = append(out, conjuncts(arg)...)
+	}
+	return out
+}
+
+// Expanding * in a :many query makes the generated code change whenever a
+// column is added to the table
+type selectStarMany struct{}
+
+func (selectStarMany) Name() string {
+	return "select-star-many"
+}
+
+func (r selectStarMany) Check(c *catalog.Catalog, raw *ast.RawStmt, q *Query) []error {
+	if q.Cmd != metadata.CmdMany {
+		return nil
+	}
+	n, ok := raw.Stmt.(*ast.SelectStmt)
+	if !ok || n.TargetList == nil {
+		return nil
+	}
+	var errs []error
+	for _, item := range n.TargetList.Items {
+		res, ok := item.(*ast.ResTarget)
+		if !ok {
+			continue
+		}
+		ref, ok := res.Val.(*ast.ColumnRef)
+		if !ok || !hasStarRef(ref) {
+			continue
+		}
+		errs = append(errs, violation(r, res.Location, "query %q selects * instead of listing columns", q.Name))
+	}
+	return errs
+}
+
+// A parameter is unused when it is declared, with sqlc.arg, @name or $1, but
+// never bound into the part of the statement PostgreSQL executes. The SELECT
+// queries of a WITH clause, at any depth, are only evaluated when the rest of
+// the statement refers to them, either directly or through another WITH query
+// that is evaluated. The generated code still requires the parameter, but its
+// value is ignored.
+type unusedParams struct{}
+
+func (unusedParams) Name() string {
+	return "unused-params"
+}
+
+func (r unusedParams) Check(c *catalog.Catalog, raw *ast.RawStmt, q *Query) []error {
+	dead := unevaluatedCTEs(raw.Stmt)
+	if len(dead) == 0 {
+		return nil
+	}
+	live := &evaluated{skip: dead, relations: map[string]int{}, params: map[int]int{}}
+	astutils.Walk(live, raw.Stmt)
+
+	names := map[int]string{}
+	for _, p := range q.Params {
+		if p.Column != nil {
+			names[p.Number] = p.Column.Name
+		}
+	}
+	reported := map[int]bool{}
+	var errs []error
+	for _, ref := range findParameters(raw.Stmt) {
+		number := ref.ref.Number
+		if live.params[number] > 0 || reported[number] {
+			continue
+		}
+		reported[number] = true
+		if name := names[number]; name != "" {
+			errs = append(errs, violation(r, ref.ref.Location, "parameter %q is only used in a WITH query that is never evaluated", name))
+		} else {
+			errs = append(errs, violation(r, ref.ref.Location, "parameter $%d is only used in a WITH query that is never evaluated", number))
+		}
+	}
+	return errs
+}
+
+// The SELECT queries of the WITH clauses in stmt which are never evaluated.
+// Every one starts out unevaluated, until a reference from the evaluated part
+// of the statement is found.
+func unevaluatedCTEs(stmt ast.Node) map[*ast.CommonTableExpr]bool {
+	dead := map[*ast.CommonTableExpr]bool{}
+	list := astutils.Search(stmt, func(n ast.Node) bool {
+		_, ok := n.(*ast.CommonTableExpr)
+		return ok
+	})
+	for _, item := range list.Items {
+		cte := item.(*ast.CommonTableExpr)
+		// Data-modifying statements are always executed
+		if _, ok := cte.Ctequery.(*ast.SelectStmt); ok && cte.Ctename != nil {
+			dead[cte] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		live := &evaluated{skip: dead, relations: map[string]int{}, params: map[int]int{}}
+		astutils.Walk(live, stmt)
+		for cte := range dead {
+			if live.relations[*cte.Ctename] > 0 {
+				delete(dead, cte)
+				changed = true
+			}
+		}
+	}
+	return dead
+}
+
+// Counts the relations and parameters referenced outside of the skipped
+// WITH queries
+type evaluated struct {
+	skip      map[*ast.CommonTableExpr]bool
+	relations map[string]int
+	params    map[int]int
+}
+
+func (e *evaluated) Visit(node ast.Node) astutils.Visitor {
+	switch n := node.(type) {
+	case *ast.CommonTableExpr:
+		if e.skip[n] {
+			return nil
+		}
+	case *ast.RangeVar:
+		if n.Relname != nil {
+			e.relations[*n.Relname]++
+		}
+	case *ast.ParamRef:
+		e.params[n.Number]++
+	}
+	return e
+}
@@ -0,0 +1,140 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/multierr"
+	"github.com/kyleconroy/sqlc/internal/opts"
+)
+
+const vetSchema = `
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);
+`
+
+const vetQueries = `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1;
+
+-- name: GetAuthorByName :one
+SELECT id, name FROM authors WHERE name = $1;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
+
+-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: DeleteAuthors :exec
+DELETE FROM authors;
+
+-- name: UpdateBio :exec
+WITH old AS (
+  SELECT sqlc.arg(old_name)::text AS name
+)
+UPDATE authors SET bio = sqlc.arg(bio) WHERE id = sqlc.arg(id);
+
+-- name: UpdateName :exec
+WITH a AS (
+  SELECT @first::text AS name
+), b AS (
+  SELECT name FROM a
+), c AS (
+  SELECT @second::text AS name
+)
+UPDATE authors SET name = c.name FROM c WHERE id = @id;
+`
+
+func TestVet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	schema := filepath.Join(dir, "schema.sql")
+	queries := filepath.Join(dir, "query.sql")
+	if err := ioutil.WriteFile(schema, []byte(vetSchema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(queries, []byte(vetQueries), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		conf config.SQLVet
+		errs []string
+	}{
+		{
+			"all",
+			config.SQLVet{},
+			[]string{
+				`5:1: one-without-limit: query "GetAuthorByName" may return more than one row; add a LIMIT or filter on a unique column`,
+				`11:8: select-star-many: query "ListAuthors" selects * instead of listing columns`,
+				`14:1: missing-where: DELETE without a WHERE clause removes every row`,
+				`18:10: unused-params: parameter "old_name" is only used in a WITH query that is never evaluated`,
+				`24:10: unused-params: parameter "first" is only used in a WITH query that is never evaluated`,
+			},
+		},
+		{
+			"enable",
+			config.SQLVet{Enable: []string{"missing-where"}},
+			[]string{
+				`14:1: missing-where: DELETE without a WHERE clause removes every row`,
+			},
+		},
+		{
+			"disable",
+			config.SQLVet{Disable: []string{"one-without-limit", "select-star-many", "unused-params"}},
+			[]string{
+				`14:1: missing-where: DELETE without a WHERE clause removes every row`,
+			},
+		},
+	} {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			conf := config.SQL{
+				Engine:  config.EnginePostgreSQL,
+				Schema:  []string{schema},
+				Queries: []string{queries},
+				Vet:     tt.conf,
+			}
+			rules, err := Rules(conf.Vet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := NewCompiler(conf, config.CombinedSettings{})
+			if err := c.ParseCatalog(conf.Schema); err != nil {
+				t.Fatal(err)
+			}
+			err = c.Vet(rules, opts.Parser{})
+			merr, ok := err.(*multierr.Error)
+			if !ok {
+				t.Fatalf("expected *multierr.Error; got %v", err)
+			}
+			var errs []string
+			for _, e := range merr.Errs() {
+				errs = append(errs, fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Err))
+			}
+			if diff := cmp.Diff(tt.errs, errs); diff != "" {
+				t.Errorf("differed (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnknownRule(t *testing.T) {
+	_, err := Rules(config.SQLVet{Disable: []string{"foo"}})
+	if err == nil {
+		t.Fatal("expected error; got nil")
+	}
+}
@@ -0,0 +1,350 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ParseConfigFile parses the configuration file at path, which must already
+// be open as rd. Version 2 files may include other files and define presets,
+// see compose. Paths in either version may refer to environment variables as
+// ${NAME}.
+func ParseConfigFile(path string, rd io.Reader) (Config, error) {
+	blob, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return Config{}, err
+	}
+	var version versionSetting
+	if err := yaml.Unmarshal(blob, &version); err != nil {
+		return Config{}, err
+	}
+
+	switch version.Number {
+	case "":
+		return Config{}, ErrMissingVersion
+	case "1":
+		if bytes.Contains(blob, []byte("${")) {
+			var doc yaml.Node
+			if err := yaml.Unmarshal(blob, &doc); err != nil {
+				return Config{}, err
+			}
+			if err := expandPaths(&doc); err != nil {
+				return Config{}, err
+			}
+			if blob, err = yaml.Marshal(&doc); err != nil {
+				return Config{}, err
+			}
+		}
+		return v1ParseConfig(bytes.NewReader(blob))
+	case "2":
+		var files []string
+		if needsCompose(blob) {
+			if blob, files, err = compose(path, blob); err != nil {
+				return Config{}, err
+			}
+		}
+		conf, err := v2ParseConfig(bytes.NewReader(blob))
+		conf.Files = files
+		return conf, err
+	default:
+		return Config{}, ErrUnknownVersion
+	}
+}
+
+// Files without includes, presets or variables are parsed as is, so that
+// errors point at the right line
+func needsCompose(blob []byte) bool {
+	var keys struct {
+		Include yaml.Node `yaml:"include"`
+		Presets yaml.Node `yaml:"presets"`
+		SQL     []struct {
+			Presets yaml.Node `yaml:"presets"`
+		} `yaml:"sql"`
+	}
+	if err := yaml.Unmarshal(blob, &keys); err != nil {
+		return true
+	}
+	for _, sql := range keys.SQL {
+		if sql.Presets.Kind != 0 {
+			return true
+		}
+	}
+	return keys.Include.Kind != 0 || keys.Presets.Kind != 0 || bytes.Contains(blob, []byte("${"))
+}
+
+// compose builds a single version 2 configuration from the file at path:
+//
+//  1. ${NAME} in paths is replaced with the value of the environment variable
+//  2. Each file listed under include is composed the same way, with its
+//     relative paths rewritten to be relative to the including file, and
+//     merged into the including file
+//  3. The presets named by each sql entry are merged into the entry
+//
+// When two values are merged, the value from the including file (or the sql
+// entry) wins over the value from the included file (or the preset). Objects
+// are merged key by key, and lists are concatenated with the items of the
+// winning side first, so that its type overrides are matched first. Presets
+// listed later win over presets listed earlier.
+//
+// It returns the composed file along with the paths of the included files.
+func compose(path string, blob []byte) ([]byte, []string, error) {
+	var files []string
+	root, err := composeFile(path, blob, filepath.Dir(path), map[string]bool{}, &files)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := applyPresets(root); err != nil {
+		return nil, nil, err
+	}
+	out, err := yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+	return out, files, err
+}
+
+func composeFile(path string, blob []byte, rootDir string, seen map[string]bool, files *[]string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(blob, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected an object", path)
+	}
+	m := doc.Content[0]
+	if err := expandPaths(m); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	var includes []string
+	if v := removeKey(m, "include"); v != nil {
+		for _, n := range scalars(v) {
+			p := n.Value
+			if !filepath.IsAbs(p) {
+				p = filepath.Join(dir, p)
+			}
+			includes = append(includes, p)
+		}
+	}
+
+	if dir != rootDir {
+		if err := rebasePaths(m, dir, rootDir); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	for _, inc := range includes {
+		abs, err := filepath.Abs(inc)
+		if err != nil {
+			return nil, err
+		}
+		if seen[abs] {
+			return nil, fmt.Errorf("%s: include cycle through %s", path, inc)
+		}
+		blob, err := ioutil.ReadFile(inc)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		seen[abs] = true
+		*files = append(*files, inc)
+		n, err := composeFile(inc, blob, rootDir, seen, files)
+		if err != nil {
+			return nil, err
+		}
+		delete(seen, abs)
+		if v := removeKey(n, "version"); v != nil && v.Value != "2" {
+			return nil, fmt.Errorf("%s: included files must be version 2", inc)
+		}
+		m = merge(n, m)
+	}
+	return m, nil
+}
+
+// Merge each sql entry with the presets it names
+func applyPresets(root *yaml.Node) error {
+	presets := removeKey(root, "presets")
+	if presets != nil && presets.Kind != yaml.MappingNode {
+		return errors.New("presets must be an object")
+	}
+	sql := lookup(root, "sql")
+	if sql == nil || sql.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for i, entry := range sql.Content {
+		if entry.Kind != yaml.MappingNode {
+			continue
+		}
+		names := removeKey(entry, "presets")
+		if names == nil {
+			continue
+		}
+		list := scalars(names)
+		for j := len(list) - 1; j >= 0; j-- {
+			var preset *yaml.Node
+			if presets != nil {
+				preset = lookup(presets, list[j].Value)
+			}
+			if preset == nil {
+				return fmt.Errorf("unknown preset %q", list[j].Value)
+			}
+			entry = merge(preset, entry)
+		}
+		sql.Content[i] = entry
+	}
+	return nil
+}
+
+// merge returns the combination of base and over, where over wins. Neither
+// node is modified.
+func merge(base, over *yaml.Node) *yaml.Node {
+	switch {
+	case base.Kind == yaml.MappingNode && over.Kind == yaml.MappingNode:
+		out := *over
+		out.Content = append([]*yaml.Node{}, over.Content...)
+		for i := 0; i+1 < len(base.Content); i += 2 {
+			key, value := base.Content[i], base.Content[i+1]
+			found := false
+			for j := 0; j+1 < len(out.Content); j += 2 {
+				if out.Content[j].Value == key.Value {
+					out.Content[j+1] = merge(value, out.Content[j+1])
+					found = true
+					break
+				}
+			}
+			if !found {
+				out.Content = append(out.Content, key, value)
+			}
+		}
+		return &out
+	case base.Kind == yaml.SequenceNode && over.Kind == yaml.SequenceNode:
+		out := *over
+		out.Content = append(append([]*yaml.Node{}, over.Content...), base.Content...)
+		return &out
+	default:
+		return over
+	}
+}
+
+// Keys whose values are file paths. Plugin options are passed through as is.
+var pathKeys = map[string]bool{
+	"cmd":     true,
+	"include": true,
+	"out":     true,
+	"path":    true,
+	"queries": true,
+	"schema":  true,
+}
+
+func walkPaths(n *yaml.Node, f func(key string, value *yaml.Node) error) error {
+	switch n.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, c := range n.Content {
+			if err := walkPaths(c, f); err != nil {
+				return err
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			switch {
+			case key == "options":
+			case pathKeys[key]:
+				for _, s := range scalars(value) {
+					if err := f(key, s); err != nil {
+						return err
+					}
+				}
+			default:
+				if err := walkPaths(value, f); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+var envVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func expandPaths(n *yaml.Node) error {
+	return walkPaths(n, func(key string, value *yaml.Node) error {
+		var missing string
+		value.Value = envVar.ReplaceAllStringFunc(value.Value, func(m string) string {
+			name := envVar.FindStringSubmatch(m)[1]
+			v, ok := os.LookupEnv(name)
+			if !ok && missing == "" {
+				missing = name
+			}
+			return v
+		})
+		if missing != "" {
+			return fmt.Errorf("environment variable %s is not set", missing)
+		}
+		return nil
+	})
+}
+
+// Make the relative paths of a file in dir relative to rootDir. Commands
+// without a slash are looked up on PATH and left alone.
+func rebasePaths(n *yaml.Node, dir, rootDir string) error {
+	return walkPaths(n, func(key string, value *yaml.Node) error {
+		if key == "include" || filepath.IsAbs(value.Value) {
+			return nil
+		}
+		if key == "cmd" && !strings.ContainsRune(value.Value, '/') {
+			return nil
+		}
+		rel, err := filepath.Rel(rootDir, filepath.Join(dir, value.Value))
+		if err != nil {
+			return err
+		}
+		if key == "cmd" && !strings.ContainsRune(rel, '/') {
+			rel = "./" + rel
+		}
+		value.Value = rel
+		return nil
+	})
+}
+
+// A scalar or a list of scalars
+func scalars(n *yaml.Node) []*yaml.Node {
+	if n.Kind == yaml.ScalarNode {
+		return []*yaml.Node{n}
+	}
+	var out []*yaml.Node
+	if n.Kind == yaml.SequenceNode {
+		for _, c := range n.Content {
+			if c.Kind == yaml.ScalarNode {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+func lookup(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func removeKey(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			v := m.Content[i+1]
+			m.Content = append(m.Content[:i:i], m.Content[i+2:]...)
+			return v
+		}
+	}
+	return nil
+}
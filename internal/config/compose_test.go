@@ -0,0 +1,190 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func parseFile(t *testing.T, path string) (Config, error) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return ParseConfigFile(path, f)
+}
+
+func TestCompose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("SQLC_TEST_SCHEMA", "migrations")
+	defer os.Unsetenv("SQLC_TEST_SCHEMA")
+
+	writeFiles(t, dir, map[string]string{
+		"sqlc.yaml": `
+version: "2"
+include:
+  - shared/presets.yaml
+  - billing/sqlc.yaml
+sql:
+  - schema: "${SQLC_TEST_SCHEMA}"
+    queries: "users/query.sql"
+    engine: "postgresql"
+    presets: ["service", "strict"]
+    gen:
+      go:
+        package: "users"
+        out: "users"
+        emit_json_tags: false
+        overrides:
+          - db_type: "uuid"
+            go_type: "string"
+`,
+		"shared/presets.yaml": `
+version: "2"
+presets:
+  service:
+    gen:
+      go:
+        emit_json_tags: true
+        emit_interface: true
+        overrides:
+          - db_type: "uuid"
+            go_type: "github.com/google/uuid.UUID"
+  strict:
+    gen:
+      go:
+        emit_exact_table_names: true
+`,
+		"billing/sqlc.yaml": `
+version: "2"
+sql:
+  - schema: "schema.sql"
+    queries: "query.sql"
+    engine: "postgresql"
+    presets: ["service"]
+    gen:
+      go:
+        out: "gen"
+`,
+	})
+
+	conf, err := parseFile(t, filepath.Join(dir, "sqlc.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid := Override{DBType: "uuid", GoType: "github.com/google/uuid.UUID"}
+	if err := uuid.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	str := Override{DBType: "uuid", GoType: "string"}
+	if err := str.Parse(); err != nil {
+		t.Fatal(err)
+	}
+	expected := []SQL{
+		{
+			Engine:  EnginePostgreSQL,
+			Schema:  Paths{"migrations"},
+			Queries: Paths{"users/query.sql"},
+			Gen: SQLGen{Go: &SQLGo{
+				Package:             "users",
+				Out:                 "users",
+				EmitJSONTags:        false,
+				EmitInterface:       true,
+				EmitExactTableNames: true,
+				Overrides:           []Override{str, uuid},
+			}},
+		},
+		{
+			Engine:  EnginePostgreSQL,
+			Schema:  Paths{"billing/schema.sql"},
+			Queries: Paths{"billing/query.sql"},
+			Gen: SQLGen{Go: &SQLGo{
+				Package:       "gen",
+				Out:           "billing/gen",
+				EmitJSONTags:  true,
+				EmitInterface: true,
+				Overrides:     []Override{uuid},
+			}},
+		},
+	}
+	if diff := cmp.Diff(expected, conf.SQL, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+	files := []string{filepath.Join(dir, "shared/presets.yaml"), filepath.Join(dir, "billing/sqlc.yaml")}
+	if diff := cmp.Diff(files, conf.Files); diff != "" {
+		t.Errorf("files differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestComposeErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	writeFiles(t, dir, map[string]string{
+		"preset.yaml": `
+version: "2"
+sql:
+  - schema: "schema.sql"
+    queries: "query.sql"
+    engine: "postgresql"
+    presets: ["missing"]
+`,
+		"env.yaml": `
+version: "2"
+sql:
+  - schema: "${SQLC_TEST_UNSET}/schema.sql"
+    queries: "query.sql"
+    engine: "postgresql"
+`,
+		"a.yaml": `
+version: "2"
+include: ["b.yaml"]
+`,
+		"b.yaml": `
+version: "2"
+include: ["a.yaml"]
+`,
+	})
+
+	for file, msg := range map[string]string{
+		"preset.yaml": `unknown preset "missing"`,
+		"env.yaml":    "environment variable SQLC_TEST_UNSET is not set",
+		"a.yaml":      "include cycle",
+	} {
+		_, err := parseFile(t, filepath.Join(dir, file))
+		if err == nil {
+			t.Errorf("%s: expected an error", file)
+			continue
+		}
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("%s: expected %q in error, got %q", file, msg, err)
+		}
+	}
+}
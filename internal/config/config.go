@@ -1,17 +1,15 @@
 package config
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"go/types"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
-	yaml "gopkg.in/yaml.v3"
-
 	"github.com/kyleconroy/sqlc/internal/core"
 )
 
@@ -79,9 +77,22 @@ const (
 )
 
 type Config struct {
-	Version string `json:"version" yaml:"version"`
-	SQL     []SQL  `json:"sql" yaml:"sql"`
-	Gen     Gen    `json:"overrides,omitempty" yaml:"overrides"`
+	Version string   `json:"version" yaml:"version"`
+	SQL     []SQL    `json:"sql" yaml:"sql"`
+	Gen     Gen      `json:"overrides,omitempty" yaml:"overrides"`
+	Plugins []Plugin `json:"plugins,omitempty" yaml:"plugins"`
+
+	// The files pulled in with include, if any
+	Files []string `json:"-" yaml:"-"`
+}
+
+// A Plugin is an external code generator. sqlc runs Cmd once per package,
+// writes the compiled package to its stdin and reads the files to write from
+// its stdout, see the plugin package.
+type Plugin struct {
+	Name string   `json:"name" yaml:"name"`
+	Cmd  string   `json:"cmd" yaml:"cmd"`
+	Args []string `json:"args,omitempty" yaml:"args"`
 }
 
 type Gen struct {
@@ -90,12 +101,14 @@ type Gen struct {
 }
 
 type GenGo struct {
-	Overrides []Override        `json:"overrides,omitempty" yaml:"overrides"`
-	Rename    map[string]string `json:"rename,omitempty" yaml:"rename"`
+	Overrides   []Override        `json:"overrides,omitempty" yaml:"overrides"`
+	Rename      map[string]string `json:"rename,omitempty" yaml:"rename"`
+	RenameRules []RenameRule      `json:"rename_rules,omitempty" yaml:"rename_rules"`
 }
 
 type GenKotlin struct {
-	Rename map[string]string `json:"rename,omitempty" yaml:"rename"`
+	Rename      map[string]string `json:"rename,omitempty" yaml:"rename"`
+	RenameRules []RenameRule      `json:"rename_rules,omitempty" yaml:"rename_rules"`
 }
 
 type SQL struct {
@@ -103,11 +116,21 @@ type SQL struct {
 	Schema  Paths  `json:"schema" yaml:"schema"`
 	Queries Paths  `json:"queries" yaml:"queries"`
 	Gen     SQLGen `json:"gen" yaml:"gen"`
+	Vet     SQLVet `json:"vet,omitempty" yaml:"vet"`
+}
+
+type SQLVet struct {
+	// If set, only run these rules
+	Enable []string `json:"enable,omitempty" yaml:"enable"`
+	// Rules which should not be run
+	Disable []string `json:"disable,omitempty" yaml:"disable"`
 }
 
 type SQLGen struct {
-	Go     *SQLGo     `json:"go,omitempty" yaml:"go"`
-	Kotlin *SQLKotlin `json:"kotlin,omitempty" yaml:"kotlin"`
+	Go      *SQLGo      `json:"go,omitempty" yaml:"go"`
+	Kotlin  *SQLKotlin  `json:"kotlin,omitempty" yaml:"kotlin"`
+	JSON    *SQLJSON    `json:"json,omitempty" yaml:"json"`
+	Plugins []SQLPlugin `json:"plugins,omitempty" yaml:"plugins"`
 }
 
 type SQLGo struct {
@@ -117,16 +140,52 @@ type SQLGo struct {
 	EmitPreparedQueries bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
 	EmitExactTableNames bool              `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
 	EmitEmptySlices     bool              `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
+	EmitPointersForNull bool              `json:"emit_pointers_for_null_types,omitempty" yaml:"emit_pointers_for_null_types"`
+	CommonInitialisms   bool              `json:"common_initialisms,omitempty" yaml:"common_initialisms"`
+	Initialisms         []string          `json:"initialisms,omitempty" yaml:"initialisms"`
+	JSONTagsCaseStyle   string            `json:"json_tags_case_style,omitempty" yaml:"json_tags_case_style"`
+	JSONTagsOmitEmpty   bool              `json:"json_tags_omitempty,omitempty" yaml:"json_tags_omitempty"`
+	StructTags          map[string]string `json:"struct_tags,omitempty" yaml:"struct_tags"`
+	SQLPackage          string            `json:"sql_package,omitempty" yaml:"sql_package"`
 	Package             string            `json:"package" yaml:"package"`
 	Out                 string            `json:"out" yaml:"out"`
 	Overrides           []Override        `json:"overrides,omitempty" yaml:"overrides"`
 	Rename              map[string]string `json:"rename,omitempty" yaml:"rename"`
+	RenameRules         []RenameRule      `json:"rename_rules,omitempty" yaml:"rename_rules"`
+	QueryStructs        []QueryStructs    `json:"query_structs,omitempty" yaml:"query_structs"`
+}
+
+// QueryStructs sets the names of the structs generated for a query's
+// parameters and output row
+type QueryStructs struct {
+	Query  string `json:"query" yaml:"query"`
+	Params string `json:"params,omitempty" yaml:"params"`
+	Row    string `json:"row,omitempty" yaml:"row"`
 }
 
 type SQLKotlin struct {
-	EmitExactTableNames bool   `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
-	Package             string `json:"package" yaml:"package"`
-	Out                 string `json:"out" yaml:"out"`
+	EmitExactTableNames bool     `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
+	CommonInitialisms   bool     `json:"common_initialisms,omitempty" yaml:"common_initialisms"`
+	Initialisms         []string `json:"initialisms,omitempty" yaml:"initialisms"`
+	Package             string   `json:"package" yaml:"package"`
+	Out                 string   `json:"out" yaml:"out"`
+}
+
+// SQLJSON writes the compiled package as versioned JSON, see the ir package
+type SQLJSON struct {
+	Package  string `json:"package" yaml:"package"`
+	Out      string `json:"out" yaml:"out"`
+	Filename string `json:"filename,omitempty" yaml:"filename"`
+	Indent   string `json:"indent,omitempty" yaml:"indent"`
+}
+
+type SQLPlugin struct {
+	// The name of an entry in the top-level plugins list
+	Plugin  string `json:"plugin" yaml:"plugin"`
+	Package string `json:"package" yaml:"package"`
+	Out     string `json:"out" yaml:"out"`
+	// Passed to the plugin as is
+	Options map[string]interface{} `json:"options,omitempty" yaml:"options"`
 }
 
 type Override struct {
@@ -145,14 +204,25 @@ type Override struct {
 	// Deprecated. Use the `nullable` property instead
 	Deprecated_Null bool `json:"null" yaml:"null"`
 
-	// fully qualified name of the column, e.g. `accounts.id`
+	// fully qualified name of the column, e.g. `accounts.id`. When query is
+	// set, the name of an output column of that query instead, e.g. `total`
 	Column string `json:"column" yaml:"column"`
 
+	// name of a query, e.g. `ListUsers`. The override applies to the
+	// parameter named param or the output column named column of the query
+	Query string `json:"query,omitempty" yaml:"query"`
+	Param string `json:"param,omitempty" yaml:"param"`
+
+	// struct tags for the field generated for the column, e.g.
+	// `validate:"required"`. They replace tags with the same key.
+	GoStructTag string `json:"go_struct_tag,omitempty" yaml:"go_struct_tag"`
+
 	ColumnName  string
 	Table       core.FQN
 	GoTypeName  string
 	GoPackage   string
 	GoBasicType bool
+	StructTags  map[string]string
 }
 
 func (o *Override) Parse() error {
@@ -174,6 +244,19 @@ func (o *Override) Parse() error {
 
 	// validate option combinations
 	switch {
+	case o.GoStructTag != "" && o.Column == "" && o.Param == "":
+		return fmt.Errorf("Override specifying `go_struct_tag` (%q) must also specify `column` or `param`", o.GoStructTag)
+	case o.GoType == "" && o.GoStructTag == "":
+		return fmt.Errorf("Override must specify one of either `go_type` or `go_struct_tag`")
+	case o.Query != "" && o.DBType != "":
+		return fmt.Errorf("Override specifying both `query` (%q) and `db_type` (%q) is not valid.", o.Query, o.DBType)
+	case o.Query != "" && o.Param != "" && o.Column != "":
+		return fmt.Errorf("Override for query %q specifying both `param` (%q) and `column` (%q) is not valid.", o.Query, o.Param, o.Column)
+	case o.Query != "" && o.Param == "" && o.Column == "":
+		return fmt.Errorf("Override for query %q must specify one of either `param` or `column`", o.Query)
+	case o.Query == "" && o.Param != "":
+		return fmt.Errorf("Override specifying `param` (%q) must also specify `query`", o.Param)
+	case o.Query != "":
 	case o.Column != "" && o.DBType != "":
 		return fmt.Errorf("Override specifying both `column` (%q) and `db_type` (%q) is not valid.", o.Column, o.DBType)
 	case o.Column == "" && o.DBType == "":
@@ -181,7 +264,7 @@ func (o *Override) Parse() error {
 	}
 
 	// validate Column
-	if o.Column != "" {
+	if o.Column != "" && o.Query == "" {
 		colParts := strings.Split(o.Column, ".")
 		switch len(colParts) {
 		case 2:
@@ -198,7 +281,19 @@ func (o *Override) Parse() error {
 		}
 	}
 
+	// validate GoStructTag
+	if o.GoStructTag != "" {
+		tags, err := ParseStructTag(o.GoStructTag)
+		if err != nil {
+			return fmt.Errorf("Override `go_struct_tag` specifier %q is not valid: %s", o.GoStructTag, err)
+		}
+		o.StructTags = tags
+	}
+
 	// validate GoType
+	if o.GoType == "" {
+		return nil
+	}
 	lastDot := strings.LastIndex(o.GoType, ".")
 	lastSlash := strings.LastIndex(o.GoType, "/")
 	typename := o.GoType
@@ -252,6 +347,74 @@ func (o *Override) Parse() error {
 	return nil
 }
 
+func validateJSONTagsCaseStyle(style string) error {
+	switch style {
+	case "", "none", "snake", "camel", "pascal":
+		return nil
+	default:
+		return fmt.Errorf("invalid json_tags_case_style %q, expected one of snake, camel, pascal or none", style)
+	}
+}
+
+const (
+	SQLPackageStandard = "database/sql"
+	SQLPackagePGXV4    = "pgx/v4"
+)
+
+func validateSQLPackage(engine Engine, pkg string, emitPreparedQueries bool) error {
+	switch pkg {
+	case "", SQLPackageStandard:
+		return nil
+	case SQLPackagePGXV4:
+		if engine != EnginePostgreSQL {
+			return fmt.Errorf("sql_package %q is only supported by the postgresql engine", pkg)
+		}
+		if emitPreparedQueries {
+			return fmt.Errorf("sql_package %q does not support emit_prepared_queries", pkg)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid sql_package %q, expected one of database/sql or pgx/v4", pkg)
+	}
+}
+
+// ParseStructTag parses a struct tag in the conventional format, e.g.
+// `json:"id" validate:"required"`, into its values keyed by tag key
+func ParseStructTag(tag string) (map[string]string, error) {
+	tags := map[string]string{}
+	for {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			return tags, nil
+		}
+		colon := strings.Index(tag, `:"`)
+		if colon <= 0 || strings.ContainsAny(tag[:colon], " \"\t") {
+			return nil, fmt.Errorf("expected key:\"value\"")
+		}
+		key := tag[:colon]
+		tag = tag[colon+1:]
+		end := 1
+		for end < len(tag) && tag[end] != '"' {
+			if tag[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(tag) {
+			return nil, fmt.Errorf("unterminated value for key %q", key)
+		}
+		value, err := strconv.Unquote(tag[:end+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for key %q", key)
+		}
+		if _, ok := tags[key]; ok {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		tags[key] = value
+		tag = tag[end+1:]
+	}
+}
+
 var ErrMissingVersion = errors.New("no version number")
 var ErrUnknownVersion = errors.New("invalid version number")
 var ErrMissingEngine = errors.New("unknown engine")
@@ -260,39 +423,37 @@ var ErrNoPackages = errors.New("no packages")
 var ErrNoPackageName = errors.New("missing package name")
 var ErrNoPackagePath = errors.New("missing package path")
 var ErrKotlinNoOutPath = errors.New("no output path")
-
+var ErrJSONNoOutPath = errors.New("no output path for json")
+var ErrPluginNoName = errors.New("missing plugin name")
+var ErrPluginNoCmd = errors.New("missing plugin cmd")
+var ErrPluginNoOutPath = errors.New("no output path for plugin")
+var ErrPluginUnsupportedEngine = errors.New("plugins are not supported by the mysql engine, use mysql:beta")
+var ErrJSONUnsupportedEngine = errors.New("json output is not supported by the mysql engine, use mysql:beta")
+
+// ParseConfig parses a configuration file. Included files are resolved
+// against the working directory; use ParseConfigFile to resolve them against
+// the directory holding the file.
 func ParseConfig(rd io.Reader) (Config, error) {
-	var buf bytes.Buffer
-	var config Config
-	var version versionSetting
-
-	ver := io.TeeReader(rd, &buf)
-	dec := yaml.NewDecoder(ver)
-	if err := dec.Decode(&version); err != nil {
-		return config, err
-	}
-	if version.Number == "" {
-		return config, ErrMissingVersion
-	}
-	switch version.Number {
-	case "1":
-		return v1ParseConfig(&buf)
-	case "2":
-		return v2ParseConfig(&buf)
-	default:
-		return config, ErrUnknownVersion
-	}
+	return ParseConfigFile("", rd)
 }
 
 type CombinedSettings struct {
-	Global    Config
-	Package   SQL
-	Go        SQLGo
-	Kotlin    SQLKotlin
-	Rename    map[string]string
-	Overrides []Override
+	Global      Config
+	Package     SQL
+	Go          SQLGo
+	Kotlin      SQLKotlin
+	JSON        SQLJSON
+	Rename      map[string]string
+	RenameRules []RenameRule
+	Overrides   []Override
 }
 
+// Combine the global settings with the settings of a single package. Global
+// type overrides come before package ones, and global rename rules run before
+// package rules. Package renames replace global renames of the same name.
+// Global Go and Kotlin settings only apply to packages generating that
+// language. Includes and presets have already been merged into conf and pkg
+// by ParseConfigFile.
 func Combine(conf Config, pkg SQL) CombinedSettings {
 	cs := CombinedSettings{
 		Global:  conf,
@@ -301,16 +462,36 @@ func Combine(conf Config, pkg SQL) CombinedSettings {
 	if conf.Gen.Go != nil {
 		cs.Rename = conf.Gen.Go.Rename
 		cs.Overrides = append(cs.Overrides, conf.Gen.Go.Overrides...)
+		if pkg.Gen.Go != nil {
+			cs.RenameRules = append(cs.RenameRules, conf.Gen.Go.RenameRules...)
+		}
 	}
 	if conf.Gen.Kotlin != nil {
 		cs.Rename = conf.Gen.Kotlin.Rename
+		if pkg.Gen.Kotlin != nil {
+			cs.RenameRules = append(cs.RenameRules, conf.Gen.Kotlin.RenameRules...)
+		}
 	}
 	if pkg.Gen.Go != nil {
 		cs.Go = *pkg.Gen.Go
 		cs.Overrides = append(cs.Overrides, pkg.Gen.Go.Overrides...)
+		cs.RenameRules = append(cs.RenameRules, pkg.Gen.Go.RenameRules...)
+	}
+	if pkg.Gen.Go != nil && len(pkg.Gen.Go.Rename) > 0 {
+		rename := map[string]string{}
+		for k, v := range cs.Rename {
+			rename[k] = v
+		}
+		for k, v := range pkg.Gen.Go.Rename {
+			rename[k] = v
+		}
+		cs.Rename = rename
 	}
 	if pkg.Gen.Kotlin != nil {
 		cs.Kotlin = *pkg.Gen.Kotlin
 	}
+	if pkg.Gen.JSON != nil {
+		cs.JSON = *pkg.Gen.JSON
+	}
 	return cs
 }
@@ -23,6 +23,41 @@ const unknownFields = `{
   "foo": "bar"
 }`
 
+const unknownPlugin = `{
+  "version": "2",
+  "sql": [{
+    "engine": "postgresql",
+    "gen": {"plugins": [{"plugin": "elixir", "out": "lib"}]}
+  }]
+}`
+
+const badRenameRule = `{
+  "version": "1",
+  "packages": [{"path": "db", "schema": "schema.sql", "queries": "query.sql"}],
+  "rename_rules": [{"match": "(url", "replace": "URL"}]
+}`
+
+const unknownRenameKind = `{
+  "version": "2",
+  "sql": [{
+    "engine": "postgresql",
+    "gen": {"go": {"out": "db", "rename_rules": [{"match": "url", "replace": "URL", "kinds": ["table"]}]}}
+  }]
+}`
+
+const pgxWithMySQL = `{
+  "version": "1",
+  "packages": [{"path": "db", "engine": "mysql", "schema": "schema.sql", "queries": "query.sql", "sql_package": "pgx/v4"}]
+}`
+
+const unknownSQLPackage = `{
+  "version": "2",
+  "sql": [{
+    "engine": "postgresql",
+    "gen": {"go": {"out": "db", "sql_package": "pgx/v5"}}
+  }]
+}`
+
 func TestBadConfigs(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -50,6 +85,31 @@ func TestBadConfigs(t *testing.T) {
   line 3: field foo not found in type config.V1GenerateSettings`,
 			unknownFields,
 		},
+		{
+			"unknown plugin",
+			`unknown plugin "elixir"`,
+			unknownPlugin,
+		},
+		{
+			"bad rename rule",
+			"Rename rule `match` \"(url\" is not a valid regular expression: error parsing regexp: missing closing ): `(url`",
+			badRenameRule,
+		},
+		{
+			"unknown rename kind",
+			"Rename rule kind \"table\" is not one of struct, field, enum or method",
+			unknownRenameKind,
+		},
+		{
+			"pgx with mysql",
+			"sql_package \"pgx/v4\" is only supported by the postgresql engine",
+			pgxWithMySQL,
+		},
+		{
+			"unknown sql package",
+			"invalid sql_package \"pgx/v5\", expected one of database/sql or pgx/v4",
+			unknownSQLPackage,
+		},
 	} {
 		tt := test
 		t.Run(tt.name, func(t *testing.T) {
@@ -135,6 +195,34 @@ func TestTypeOverrides(t *testing.T) {
 			},
 			"Package override `go_type` specifier \"untyped rune\" is not a Go basic type e.g. 'string'",
 		},
+		{
+			Override{
+				Query:  "ListAuthors",
+				GoType: "string",
+			},
+			"Override for query \"ListAuthors\" must specify one of either `param` or `column`",
+		},
+		{
+			Override{
+				Param:  "cursor",
+				GoType: "int",
+			},
+			"Override specifying `param` (\"cursor\") must also specify `query`",
+		},
+		{
+			Override{
+				DBType:      "uuid",
+				GoStructTag: `validate:"uuid"`,
+			},
+			"Override specifying `go_struct_tag` (\"validate:\\\"uuid\\\"\") must also specify `column` or `param`",
+		},
+		{
+			Override{
+				Column:      "users.email",
+				GoStructTag: `validate:"email`,
+			},
+			"Override `go_struct_tag` specifier \"validate:\\\"email\" is not valid: unterminated value for key \"validate\"",
+		},
 	} {
 		tt := test
 		t.Run(tt.override.GoType, func(t *testing.T) {
@@ -148,3 +236,65 @@ func TestTypeOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStructTag(t *testing.T) {
+	tags, err := ParseStructTag(`validate:"required,email"  mapstructure:"e\"mail"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"validate":     "required,email",
+		"mapstructure": `e"mail`,
+	}
+	if diff := cmp.Diff(want, tags); diff != "" {
+		t.Errorf("tags mismatch;\n%s", diff)
+	}
+	for _, tag := range []string{`validate`, `validate:required`, `validate:"a" validate:"b"`, `:"a"`} {
+		if _, err := ParseStructTag(tag); err == nil {
+			t.Errorf("expected %q to fail", tag)
+		}
+	}
+}
+
+func TestCombineOrder(t *testing.T) {
+	conf, err := ParseConfig(strings.NewReader(`{
+  "version": "2",
+  "overrides": {
+    "go": {
+      "overrides": [{"db_type": "uuid", "go_type": "github.com/global/uuid.UUID"}],
+      "rename_rules": [{"match": "^a", "replace": "b"}]
+    },
+    "kotlin": {
+      "rename_rules": [{"match": "^a", "replace": "k"}]
+    }
+  },
+  "sql": [{
+    "schema": "schema.sql",
+    "queries": "query.sql",
+    "engine": "postgresql",
+    "gen": {
+      "go": {
+        "package": "db",
+        "out": "db",
+        "overrides": [{"db_type": "uuid", "go_type": "github.com/pkg/uuid.UUID"}],
+        "rename_rules": [{"match": "^b", "replace": "c"}]
+      }
+    }
+  }]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs := Combine(conf, conf.SQL[0])
+	var types []string
+	for _, o := range cs.Overrides {
+		types = append(types, o.GoPackage)
+	}
+	if diff := cmp.Diff([]string{"github.com/global/uuid", "github.com/pkg/uuid"}, types); diff != "" {
+		t.Errorf("overrides mismatch;\n%s", diff)
+	}
+	// The global Go rule runs first and the Kotlin rule isn't used
+	if name := cs.ApplyRenameRules(RenameField, "a"); name != "c" {
+		t.Errorf("expected rename rules to produce c, got %q", name)
+	}
+}
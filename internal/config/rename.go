@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The kinds of names rename rules apply to
+const (
+	RenameStruct = "struct"
+	RenameField  = "field"
+	RenameEnum   = "enum"
+	RenameMethod = "method"
+)
+
+// A RenameRule rewrites the SQL names matching a regular expression before
+// they are turned into identifiers. Rules run in order, each on the output of
+// the previous one. Entries in the rename map win over every rule.
+type RenameRule struct {
+	// regular expression, e.g. `^tbl_`
+	Match string `json:"match" yaml:"match"`
+	// replacement, which may refer to submatches as $1 or ${name}
+	Replace string `json:"replace" yaml:"replace"`
+	// the kinds of names to rewrite: struct, field, enum or method. Defaults
+	// to all of them.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds"`
+
+	re *regexp.Regexp
+}
+
+func (r *RenameRule) Parse() error {
+	if r.Match == "" {
+		return fmt.Errorf("Rename rule must specify `match`")
+	}
+	re, err := regexp.Compile(r.Match)
+	if err != nil {
+		return fmt.Errorf("Rename rule `match` %q is not a valid regular expression: %s", r.Match, err)
+	}
+	for _, k := range r.Kinds {
+		switch k {
+		case RenameStruct, RenameField, RenameEnum, RenameMethod:
+		default:
+			return fmt.Errorf("Rename rule kind %q is not one of struct, field, enum or method", k)
+		}
+	}
+	r.re = re
+	return nil
+}
+
+func (r *RenameRule) appliesTo(kind string) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, k := range r.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRenameRules(rules []RenameRule) error {
+	for i := range rules {
+		if err := rules[i].Parse(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyRenameRules rewrites the SQL name of an identifier of the given kind
+// with each matching rename rule, in order
+func (s CombinedSettings) ApplyRenameRules(kind, name string) string {
+	for i := range s.RenameRules {
+		r := &s.RenameRules[i]
+		if r.re == nil || !r.appliesTo(kind) {
+			continue
+		}
+		name = r.re.ReplaceAllString(name, r.Replace)
+	}
+	return name
+}
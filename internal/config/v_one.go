@@ -13,21 +13,33 @@ type V1GenerateSettings struct {
 	Packages  []v1PackageSettings `json:"packages" yaml:"packages"`
 	Overrides []Override          `json:"overrides,omitempty" yaml:"overrides,omitempty"`
 	Rename    map[string]string   `json:"rename,omitempty" yaml:"rename,omitempty"`
+	// Applied to every package, after the package's own rules
+	RenameRules []RenameRule `json:"rename_rules,omitempty" yaml:"rename_rules,omitempty"`
 }
 
 type v1PackageSettings struct {
-	Name                string     `json:"name" yaml:"name"`
-	Engine              Engine     `json:"engine,omitempty" yaml:"engine"`
-	Path                string     `json:"path" yaml:"path"`
-	Schema              Paths      `json:"schema" yaml:"schema"`
-	Queries             Paths      `json:"queries" yaml:"queries"`
-	EmitInterface       bool       `json:"emit_interface" yaml:"emit_interface"`
-	EmitJSONTags        bool       `json:"emit_json_tags" yaml:"emit_json_tags"`
-	EmitDBTags          bool       `json:"emit_db_tags" yaml:"emit_db_tags"`
-	EmitPreparedQueries bool       `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
-	EmitExactTableNames bool       `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
-	EmitEmptySlices     bool       `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
-	Overrides           []Override `json:"overrides" yaml:"overrides"`
+	Name                string            `json:"name" yaml:"name"`
+	Engine              Engine            `json:"engine,omitempty" yaml:"engine"`
+	Path                string            `json:"path" yaml:"path"`
+	Schema              Paths             `json:"schema" yaml:"schema"`
+	Queries             Paths             `json:"queries" yaml:"queries"`
+	EmitInterface       bool              `json:"emit_interface" yaml:"emit_interface"`
+	EmitJSONTags        bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitDBTags          bool              `json:"emit_db_tags" yaml:"emit_db_tags"`
+	EmitPreparedQueries bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	EmitExactTableNames bool              `json:"emit_exact_table_names,omitempty" yaml:"emit_exact_table_names"`
+	EmitEmptySlices     bool              `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
+	EmitPointersForNull bool              `json:"emit_pointers_for_null_types,omitempty" yaml:"emit_pointers_for_null_types"`
+	CommonInitialisms   bool              `json:"common_initialisms,omitempty" yaml:"common_initialisms"`
+	Initialisms         []string          `json:"initialisms,omitempty" yaml:"initialisms"`
+	JSONTagsCaseStyle   string            `json:"json_tags_case_style,omitempty" yaml:"json_tags_case_style"`
+	JSONTagsOmitEmpty   bool              `json:"json_tags_omitempty,omitempty" yaml:"json_tags_omitempty"`
+	StructTags          map[string]string `json:"struct_tags,omitempty" yaml:"struct_tags"`
+	SQLPackage          string            `json:"sql_package,omitempty" yaml:"sql_package"`
+	Overrides           []Override        `json:"overrides" yaml:"overrides"`
+	RenameRules         []RenameRule      `json:"rename_rules,omitempty" yaml:"rename_rules"`
+	QueryStructs        []QueryStructs    `json:"query_structs,omitempty" yaml:"query_structs"`
+	Vet                 SQLVet            `json:"vet,omitempty" yaml:"vet"`
 }
 
 func v1ParseConfig(rd io.Reader) (Config, error) {
@@ -55,6 +67,9 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 			return config, err
 		}
 	}
+	if err := parseRenameRules(settings.RenameRules); err != nil {
+		return config, err
+	}
 	for j := range settings.Packages {
 		if settings.Packages[j].Path == "" {
 			return config, ErrNoPackagePath
@@ -64,12 +79,21 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 				return config, err
 			}
 		}
+		if err := parseRenameRules(settings.Packages[j].RenameRules); err != nil {
+			return config, err
+		}
+		if err := validateJSONTagsCaseStyle(settings.Packages[j].JSONTagsCaseStyle); err != nil {
+			return config, err
+		}
 		if settings.Packages[j].Name == "" {
 			settings.Packages[j].Name = filepath.Base(settings.Packages[j].Path)
 		}
 		if settings.Packages[j].Engine == "" {
 			settings.Packages[j].Engine = EnginePostgreSQL
 		}
+		if err := validateSQLPackage(settings.Packages[j].Engine, settings.Packages[j].SQLPackage, settings.Packages[j].EmitPreparedQueries); err != nil {
+			return config, err
+		}
 	}
 	return settings.Translate(), nil
 }
@@ -87,6 +111,9 @@ func (c *V1GenerateSettings) ValidateGlobalOverrides() error {
 		if usesMultipleEngines && oride.Engine == "" {
 			return fmt.Errorf(`the "engine" field is required for global type overrides because your configuration uses multiple database engines`)
 		}
+		if oride.Query != "" {
+			return fmt.Errorf("global type overrides may not specify `query` (%q)", oride.Query)
+		}
 	}
 	return nil
 }
@@ -101,6 +128,7 @@ func (c *V1GenerateSettings) Translate() Config {
 			Engine:  pkg.Engine,
 			Schema:  pkg.Schema,
 			Queries: pkg.Queries,
+			Vet:     pkg.Vet,
 			Gen: SQLGen{
 				Go: &SQLGo{
 					EmitInterface:       pkg.EmitInterface,
@@ -109,18 +137,28 @@ func (c *V1GenerateSettings) Translate() Config {
 					EmitPreparedQueries: pkg.EmitPreparedQueries,
 					EmitExactTableNames: pkg.EmitExactTableNames,
 					EmitEmptySlices:     pkg.EmitEmptySlices,
+					EmitPointersForNull: pkg.EmitPointersForNull,
+					CommonInitialisms:   pkg.CommonInitialisms,
+					Initialisms:         pkg.Initialisms,
+					JSONTagsCaseStyle:   pkg.JSONTagsCaseStyle,
+					JSONTagsOmitEmpty:   pkg.JSONTagsOmitEmpty,
+					StructTags:          pkg.StructTags,
+					SQLPackage:          pkg.SQLPackage,
 					Package:             pkg.Name,
 					Out:                 pkg.Path,
 					Overrides:           pkg.Overrides,
+					RenameRules:         pkg.RenameRules,
+					QueryStructs:        pkg.QueryStructs,
 				},
 			},
 		})
 	}
 
-	if len(c.Overrides) > 0 || len(c.Rename) > 0 {
+	if len(c.Overrides) > 0 || len(c.Rename) > 0 || len(c.RenameRules) > 0 {
 		conf.Gen.Go = &GenGo{
-			Overrides: c.Overrides,
-			Rename:    c.Rename,
+			Overrides:   c.Overrides,
+			Rename:      c.Rename,
+			RenameRules: c.RenameRules,
 		}
 	}
 
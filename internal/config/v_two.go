@@ -27,12 +27,23 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 	if err := conf.validateGlobalOverrides(); err != nil {
 		return conf, err
 	}
+	if err := conf.validatePlugins(); err != nil {
+		return conf, err
+	}
 	if conf.Gen.Go != nil {
 		for i := range conf.Gen.Go.Overrides {
 			if err := conf.Gen.Go.Overrides[i].Parse(); err != nil {
 				return conf, err
 			}
 		}
+		if err := parseRenameRules(conf.Gen.Go.RenameRules); err != nil {
+			return conf, err
+		}
+	}
+	if conf.Gen.Kotlin != nil {
+		if err := parseRenameRules(conf.Gen.Kotlin.RenameRules); err != nil {
+			return conf, err
+		}
 	}
 	for j := range conf.SQL {
 		if conf.SQL[j].Engine == "" {
@@ -50,6 +61,15 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 					return conf, err
 				}
 			}
+			if err := parseRenameRules(conf.SQL[j].Gen.Go.RenameRules); err != nil {
+				return conf, err
+			}
+			if err := validateJSONTagsCaseStyle(conf.SQL[j].Gen.Go.JSONTagsCaseStyle); err != nil {
+				return conf, err
+			}
+			if err := validateSQLPackage(conf.SQL[j].Engine, conf.SQL[j].Gen.Go.SQLPackage, conf.SQL[j].Gen.Go.EmitPreparedQueries); err != nil {
+				return conf, err
+			}
 		}
 		if conf.SQL[j].Gen.Kotlin != nil {
 			if conf.SQL[j].Gen.Kotlin.Out == "" {
@@ -59,10 +79,62 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 				return conf, ErrNoPackageName
 			}
 		}
+		if conf.SQL[j].Gen.JSON != nil {
+			if conf.SQL[j].Engine == EngineMySQL {
+				return conf, ErrJSONUnsupportedEngine
+			}
+			if conf.SQL[j].Gen.JSON.Out == "" {
+				return conf, ErrJSONNoOutPath
+			}
+			if conf.SQL[j].Gen.JSON.Package == "" {
+				conf.SQL[j].Gen.JSON.Package = filepath.Base(conf.SQL[j].Gen.JSON.Out)
+			}
+		}
+		for i := range conf.SQL[j].Gen.Plugins {
+			p := &conf.SQL[j].Gen.Plugins[i]
+			if conf.SQL[j].Engine == EngineMySQL {
+				return conf, ErrPluginUnsupportedEngine
+			}
+			if _, ok := conf.plugin(p.Plugin); !ok {
+				return conf, fmt.Errorf("unknown plugin %q", p.Plugin)
+			}
+			if p.Out == "" {
+				return conf, ErrPluginNoOutPath
+			}
+			if p.Package == "" {
+				p.Package = filepath.Base(p.Out)
+			}
+		}
 	}
 	return conf, nil
 }
 
+func (c *Config) validatePlugins() error {
+	seen := map[string]struct{}{}
+	for _, p := range c.Plugins {
+		if p.Name == "" {
+			return ErrPluginNoName
+		}
+		if p.Cmd == "" {
+			return ErrPluginNoCmd
+		}
+		if _, ok := seen[p.Name]; ok {
+			return fmt.Errorf("duplicate plugin %q", p.Name)
+		}
+		seen[p.Name] = struct{}{}
+	}
+	return nil
+}
+
+func (c *Config) plugin(name string) (Plugin, bool) {
+	for _, p := range c.Plugins {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Plugin{}, false
+}
+
 func (c *Config) validateGlobalOverrides() error {
 	engines := map[Engine]struct{}{}
 	for _, pkg := range c.SQL {
@@ -78,6 +150,9 @@ func (c *Config) validateGlobalOverrides() error {
 		if usesMultipleEngines && oride.Engine == "" {
 			return fmt.Errorf(`the "engine" field is required for global type overrides because your configuration uses multiple database engines`)
 		}
+		if oride.Query != "" {
+			return fmt.Errorf("global type overrides may not specify `query` (%q)", oride.Query)
+		}
 	}
 	return nil
 }
@@ -14,6 +14,12 @@ import (
 	"github.com/kyleconroy/sqlc/internal/cmd"
 )
 
+// Keep the tests from filling the user's cache directory
+func TestMain(m *testing.M) {
+	os.Setenv("SQLCCACHE", "off")
+	os.Exit(m.Run())
+}
+
 func TestExamples(t *testing.T) {
 	t.Parallel()
 	examples, err := filepath.Abs(filepath.Join("..", "..", "examples"))
@@ -35,7 +41,7 @@ func TestExamples(t *testing.T) {
 			t.Parallel()
 			path := filepath.Join(examples, tc)
 			var stderr bytes.Buffer
-			output, err := cmd.Generate(cmd.Env{}, path, &stderr)
+			output, err := cmd.Generate(cmd.Env{}, path, "", &stderr)
 			if err != nil {
 				t.Fatalf("sqlc generate failed: %s", stderr.String())
 			}
@@ -62,7 +68,7 @@ func BenchmarkExamples(b *testing.B) {
 			path := filepath.Join(examples, tc)
 			for i := 0; i < b.N; i++ {
 				var stderr bytes.Buffer
-				cmd.Generate(cmd.Env{}, path, &stderr)
+				cmd.Generate(cmd.Env{}, path, "", &stderr)
 			}
 		})
 	}
@@ -91,7 +97,7 @@ func TestReplay(t *testing.T) {
 			path, _ := filepath.Abs(tc)
 			var stderr bytes.Buffer
 			expected := expectedStderr(t, path)
-			output, err := cmd.Generate(cmd.Env{}, path, &stderr)
+			output, err := cmd.Generate(cmd.Env{}, path, "", &stderr)
 			if len(expected) == 0 && err != nil {
 				t.Fatalf("sqlc generate failed: %s", stderr.String())
 			}
@@ -112,7 +118,10 @@ func cmpDirectory(t *testing.T, dir string, actual map[string]string) {
 		if file.IsDir() {
 			return nil
 		}
-		if !strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, ".kt") {
+		if !strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, ".kt") && !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		if file.Name() == "sqlc.json" {
 			return nil
 		}
 		if strings.HasSuffix(path, "_test.go") || strings.Contains(path, "src/test/") {
@@ -185,7 +194,7 @@ func BenchmarkReplay(b *testing.B) {
 			path, _ := filepath.Abs(tc)
 			for i := 0; i < b.N; i++ {
 				var stderr bytes.Buffer
-				cmd.Generate(cmd.Env{}, path, &stderr)
+				cmd.Generate(cmd.Env{}, path, "", &stderr)
 			}
 		})
 	}
@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+)
+
+var (
+	ErrBatchAlreadyClosed = errors.New("batch already closed")
+)
+
+const createAuthors = `-- name: CreateAuthors :batchone
+INSERT INTO authors (name, bio)
+VALUES ($1, $2)
+RETURNING id, name, bio
+`
+
+type CreateAuthorsBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type CreateAuthorsParams struct {
+	Name string
+	Bio  sql.NullString
+}
+
+func (q *Queries) CreateAuthors(ctx context.Context, arg []CreateAuthorsParams) *CreateAuthorsBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.Name,
+			a.Bio,
+		}
+		batch.Queue(createAuthors, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &CreateAuthorsBatchResults{br, len(arg), false}
+}
+
+func (b *CreateAuthorsBatchResults) QueryRow(f func(int, Author, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var i Author
+		if b.closed {
+			if f != nil {
+				f(t, i, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		row := b.br.QueryRow()
+		err := row.Scan(&i.ID, &i.Name, &i.Bio)
+		if f != nil {
+			f(t, i, err)
+		}
+	}
+}
+
+func (b *CreateAuthorsBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const listBookTitles = `-- name: ListBookTitles :batchmany
+SELECT title FROM books
+WHERE author_id = $1
+`
+
+type ListBookTitlesBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+func (q *Queries) ListBookTitles(ctx context.Context, authorID []int64) *ListBookTitlesBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range authorID {
+		vals := []interface{}{
+			a,
+		}
+		batch.Queue(listBookTitles, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &ListBookTitlesBatchResults{br, len(authorID), false}
+}
+
+func (b *ListBookTitlesBatchResults) Query(f func(int, []string, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		var items []string
+		if b.closed {
+			if f != nil {
+				f(t, items, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		err := func() error {
+			rows, err := b.br.Query()
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				var title string
+				if err := rows.Scan(&title); err != nil {
+					return err
+				}
+				items = append(items, title)
+			}
+			return rows.Err()
+		}()
+		if f != nil {
+			f(t, items, err)
+		}
+	}
+}
+
+func (b *ListBookTitlesBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const updateAuthorNames = `-- name: UpdateAuthorNames :batchexec
+UPDATE authors SET name = $2
+WHERE id = $1
+`
+
+type UpdateAuthorNamesBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type UpdateAuthorNamesParams struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) UpdateAuthorNames(ctx context.Context, arg []UpdateAuthorNamesParams) *UpdateAuthorNamesBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.ID,
+			a.Name,
+		}
+		batch.Queue(updateAuthorNames, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &UpdateAuthorNamesBatchResults{br, len(arg), false}
+}
+
+func (b *UpdateAuthorNamesBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *UpdateAuthorNamesBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
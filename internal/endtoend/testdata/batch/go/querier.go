@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+)
+
+type Querier interface {
+	CreateAuthors(ctx context.Context, arg []CreateAuthorsParams) *CreateAuthorsBatchResults
+	GetAuthor(ctx context.Context, id int64) (Author, error)
+	ListBookTitles(ctx context.Context, authorID []int64) *ListBookTitlesBatchResults
+	UpdateAuthorNames(ctx context.Context, arg []UpdateAuthorNamesParams) *UpdateAuthorNamesBatchResults
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,28 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);
+
+CREATE TABLE books (
+  id        BIGSERIAL PRIMARY KEY,
+  author_id bigint    NOT NULL REFERENCES authors(id),
+  title     text      NOT NULL
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: CreateAuthors :batchone
+INSERT INTO authors (name, bio)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateAuthorNames :batchexec
+UPDATE authors SET name = $2
+WHERE id = $1;
+
+-- name: ListBookTitles :batchmany
+SELECT title FROM books
+WHERE author_id = $1;
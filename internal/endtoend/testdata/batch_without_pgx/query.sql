@@ -0,0 +1,7 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL
+);
+
+-- name: DeleteAuthors :batchexec
+DELETE FROM authors WHERE id = $1;
@@ -0,0 +1,16 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Querier interface {
+	InsertSingleValue(ctx context.Context, id []uuid.UUID) (int64, error)
+	InsertValues(ctx context.Context, arg []InsertValuesParams) (int64, error)
+}
+
+var _ Querier = (*Queries)(nil)
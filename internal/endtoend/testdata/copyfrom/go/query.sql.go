@@ -0,0 +1,82 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// iteratorForInsertSingleValue implements pgx.CopyFromSource
+type iteratorForInsertSingleValue struct {
+	rows                 []uuid.UUID
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertSingleValue) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertSingleValue) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0],
+	}, nil
+}
+
+func (r iteratorForInsertSingleValue) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertSingleValue(ctx context.Context, id []uuid.UUID) (int64, error) {
+	return q.db.CopyFrom(ctx, pgx.Identifier{"bar"}, []string{"id"}, &iteratorForInsertSingleValue{rows: id})
+}
+
+type InsertValuesParams struct {
+	A sql.NullString
+	B sql.NullInt32
+}
+
+// iteratorForInsertValues implements pgx.CopyFromSource
+type iteratorForInsertValues struct {
+	rows                 []InsertValuesParams
+	skippedFirstNextCall bool
+}
+
+func (r *iteratorForInsertValues) Next() bool {
+	if len(r.rows) == 0 {
+		return false
+	}
+	if !r.skippedFirstNextCall {
+		r.skippedFirstNextCall = true
+		return true
+	}
+	r.rows = r.rows[1:]
+	return len(r.rows) > 0
+}
+
+func (r iteratorForInsertValues) Values() ([]interface{}, error) {
+	return []interface{}{
+		r.rows[0].A,
+		r.rows[0].B,
+	}, nil
+}
+
+func (r iteratorForInsertValues) Err() error {
+	return nil
+}
+
+func (q *Queries) InsertValues(ctx context.Context, arg []InsertValuesParams) (int64, error) {
+	return q.db.CopyFrom(ctx, pgx.Identifier{"myschema", "foo"}, []string{"a", "b"}, &iteratorForInsertValues{rows: arg})
+}
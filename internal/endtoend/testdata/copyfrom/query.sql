@@ -0,0 +1,9 @@
+CREATE SCHEMA myschema;
+CREATE TABLE myschema.foo (a text, b integer);
+CREATE TABLE bar (id uuid NOT NULL);
+
+-- name: InsertValues :copyfrom
+INSERT INTO myschema.foo (b, a) VALUES ($2, $1);
+
+-- name: InsertSingleValue :copyfrom
+INSERT INTO bar (id) VALUES ($1);
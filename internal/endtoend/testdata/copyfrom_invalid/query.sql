@@ -0,0 +1,16 @@
+CREATE TABLE foo (a text, b integer);
+
+-- name: InsertExpression :copyfrom
+INSERT INTO foo (a, b) VALUES ($1, $2 + 1);
+
+-- name: InsertReturning :copyfrom
+INSERT INTO foo (a, b) VALUES ($1, $2) RETURNING a;
+
+-- name: InsertSelect :copyfrom
+INSERT INTO foo (a, b) SELECT a, b FROM foo;
+
+-- name: InsertWithoutColumns :copyfrom
+INSERT INTO foo VALUES ($1, $2);
+
+-- name: UpdateFoo :copyfrom
+UPDATE foo SET a = $1;
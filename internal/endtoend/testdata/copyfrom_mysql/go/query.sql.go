@@ -0,0 +1,23 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+type CreateAuthorsParams struct {
+	Name string
+	Bio  sql.NullString
+}
+
+func (q *Queries) CreateAuthors(ctx context.Context, arg []CreateAuthorsParams) (int64, error) {
+	return q.copyFrom(ctx, "INSERT INTO `authors` (`name`, `bio`) VALUES ", "(?, ?)", len(arg), func(i int) []interface{} {
+		return []interface{}{
+			arg[i].Name,
+			arg[i].Bio,
+		}
+	})
+}
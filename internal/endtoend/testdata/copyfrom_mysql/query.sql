@@ -0,0 +1,8 @@
+CREATE TABLE authors (
+  id   bigint PRIMARY KEY AUTO_INCREMENT,
+  name text   NOT NULL,
+  bio  text
+);
+
+/* name: CreateAuthors :copyfrom */
+INSERT INTO authors (name, bio) VALUES (?, ?);
@@ -0,0 +1,2 @@
+CREATE TABLE foo (id integer NOT NULL);
+CREATE OR REPLACE VIEW foo AS SELECT 1 AS id;
@@ -0,0 +1,82 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const getPerson = `-- name: GetPerson :one
+SELECT id, name, nickname, age, height, score, active, born_at, external_id, current, tags, data FROM people WHERE id = $1
+`
+
+func (q *Queries) GetPerson(ctx context.Context, id int64) (Person, error) {
+	row := q.db.QueryRowContext(ctx, getPerson, id)
+	var i Person
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Nickname,
+		&i.Age,
+		&i.Height,
+		&i.Score,
+		&i.Active,
+		&i.BornAt,
+		&i.ExternalID,
+		&i.Current,
+		pq.Array(&i.Tags),
+		&i.Data,
+	)
+	return i, err
+}
+
+const listPeopleByMood = `-- name: ListPeopleByMood :many
+SELECT id, nickname, current FROM people WHERE current = $1
+`
+
+type ListPeopleByMoodRow struct {
+	ID       int64
+	Nickname *string
+	Current  *Mood
+}
+
+func (q *Queries) ListPeopleByMood(ctx context.Context, current *Mood) ([]ListPeopleByMoodRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPeopleByMood, current)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPeopleByMoodRow
+	for rows.Next() {
+		var i ListPeopleByMoodRow
+		if err := rows.Scan(&i.ID, &i.Nickname, &i.Current); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateNickname = `-- name: UpdateNickname :exec
+UPDATE people SET nickname = $2, age = $3 WHERE id = $1
+`
+
+type UpdateNicknameParams struct {
+	ID       int64
+	Nickname *string
+	Age      *int32
+}
+
+func (q *Queries) UpdateNickname(ctx context.Context, arg UpdateNicknameParams) error {
+	_, err := q.db.ExecContext(ctx, updateNickname, arg.ID, arg.Nickname, arg.Age)
+	return err
+}
@@ -0,0 +1,25 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE people (
+  id          BIGSERIAL   PRIMARY KEY,
+  name        text        NOT NULL,
+  nickname    text,
+  age         integer,
+  height      real,
+  score       numeric,
+  active      boolean,
+  born_at     timestamptz,
+  external_id uuid,
+  current     mood,
+  tags        text[],
+  data        jsonb
+);
+
+-- name: GetPerson :one
+SELECT * FROM people WHERE id = $1;
+
+-- name: ListPeopleByMood :many
+SELECT id, nickname, current FROM people WHERE current = $1;
+
+-- name: UpdateNickname :exec
+UPDATE people SET nickname = $2, age = $3 WHERE id = $1;
@@ -0,0 +1,252 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const aggregates = `-- name: Aggregates :one
+SELECT count(*) AS items, sum(quantity) AS quantity, max(price) AS max_price
+FROM items
+`
+
+type AggregatesRow struct {
+	Items    int64
+	Quantity sql.NullInt64
+	MaxPrice sql.NullString
+}
+
+func (q *Queries) Aggregates(ctx context.Context) (AggregatesRow, error) {
+	row := q.db.QueryRowContext(ctx, aggregates)
+	var i AggregatesRow
+	err := row.Scan(&i.Items, &i.Quantity, &i.MaxPrice)
+	return i, err
+}
+
+const arithmetic = `-- name: Arithmetic :one
+SELECT quantity * 2 AS doubled, price * quantity AS total, quantity - discount AS remaining
+FROM items WHERE id = $1
+`
+
+type ArithmeticRow struct {
+	Doubled   int32
+	Total     string
+	Remaining sql.NullInt32
+}
+
+func (q *Queries) Arithmetic(ctx context.Context, id int64) (ArithmeticRow, error) {
+	row := q.db.QueryRowContext(ctx, arithmetic, id)
+	var i ArithmeticRow
+	err := row.Scan(&i.Doubled, &i.Total, &i.Remaining)
+	return i, err
+}
+
+const casts = `-- name: Casts :one
+SELECT quantity::bigint AS big_quantity, discount::text AS discount_text
+FROM items WHERE id = $1
+`
+
+type CastsRow struct {
+	BigQuantity  int64
+	DiscountText sql.NullString
+}
+
+func (q *Queries) Casts(ctx context.Context, id int64) (CastsRow, error) {
+	row := q.db.QueryRowContext(ctx, casts, id)
+	var i CastsRow
+	err := row.Scan(&i.BigQuantity, &i.DiscountText)
+	return i, err
+}
+
+const conditionals = `-- name: Conditionals :one
+SELECT
+  CASE WHEN discount > 0 THEN 'sale' ELSE 'full' END AS label,
+  CASE WHEN discount > 0 THEN discount END AS sale,
+  COALESCE(discount, 0) AS discount,
+  NULLIF(name, '') AS name,
+  GREATEST(quantity, discount) AS most
+FROM items WHERE id = $1
+`
+
+type ConditionalsRow struct {
+	Label    string
+	Sale     sql.NullInt32
+	Discount int32
+	Name     sql.NullString
+	Most     int32
+}
+
+func (q *Queries) Conditionals(ctx context.Context, id int64) (ConditionalsRow, error) {
+	row := q.db.QueryRowContext(ctx, conditionals, id)
+	var i ConditionalsRow
+	err := row.Scan(
+		&i.Label,
+		&i.Sale,
+		&i.Discount,
+		&i.Name,
+		&i.Most,
+	)
+	return i, err
+}
+
+const listNicknames = `-- name: ListNicknames :many
+SELECT lower(nickname) AS nickname, upper(name) AS name FROM items
+`
+
+type ListNicknamesRow struct {
+	Nickname sql.NullString
+	Name     string
+}
+
+func (q *Queries) ListNicknames(ctx context.Context) ([]ListNicknamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNicknames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNicknamesRow
+	for rows.Next() {
+		var i ListNicknamesRow
+		if err := rows.Scan(&i.Nickname, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lookups = `-- name: Lookups :one
+SELECT
+  array_position(ARRAY[1, 2], 3) AS position,
+  array_position(tags, name) AS tag_position,
+  length(name) AS name_length,
+  md5(name) AS hash
+FROM items WHERE id = $1
+`
+
+type LookupsRow struct {
+	Position    sql.NullInt32
+	TagPosition sql.NullInt32
+	NameLength  int32
+	Hash        string
+}
+
+func (q *Queries) Lookups(ctx context.Context, id int64) (LookupsRow, error) {
+	row := q.db.QueryRowContext(ctx, lookups, id)
+	var i LookupsRow
+	err := row.Scan(
+		&i.Position,
+		&i.TagPosition,
+		&i.NameLength,
+		&i.Hash,
+	)
+	return i, err
+}
+
+const predicates = `-- name: Predicates :one
+SELECT
+  quantity > 10 AS many,
+  discount IS NULL AS full_price,
+  name LIKE 'a%' AS starts_with_a,
+  quantity BETWEEN 1 AND 10 AS few,
+  discount IN (1, 2) AS small_discount
+FROM items WHERE id = $1
+`
+
+type PredicatesRow struct {
+	Many          bool
+	FullPrice     bool
+	StartsWithA   bool
+	Few           bool
+	SmallDiscount sql.NullBool
+}
+
+func (q *Queries) Predicates(ctx context.Context, id int64) (PredicatesRow, error) {
+	row := q.db.QueryRowContext(ctx, predicates, id)
+	var i PredicatesRow
+	err := row.Scan(
+		&i.Many,
+		&i.FullPrice,
+		&i.StartsWithA,
+		&i.Few,
+		&i.SmallDiscount,
+	)
+	return i, err
+}
+
+const soldOut = `-- name: SoldOut :one
+SELECT EXISTS (SELECT 1 FROM items WHERE quantity = 0) AS sold_out
+`
+
+func (q *Queries) SoldOut(ctx context.Context) (bool, error) {
+	row := q.db.QueryRowContext(ctx, soldOut)
+	var sold_out bool
+	err := row.Scan(&sold_out)
+	return sold_out, err
+}
+
+const strings = `-- name: Strings :one
+SELECT name || ' ' || nickname AS full_name, name || '!' AS shout, tags || name AS more_tags
+FROM items WHERE id = $1
+`
+
+type StringsRow struct {
+	FullName sql.NullString
+	Shout    string
+	MoreTags []string
+}
+
+func (q *Queries) Strings(ctx context.Context, id int64) (StringsRow, error) {
+	row := q.db.QueryRowContext(ctx, strings, id)
+	var i StringsRow
+	err := row.Scan(&i.FullName, &i.Shout, pq.Array(&i.MoreTags))
+	return i, err
+}
+
+const windows = `-- name: Windows :many
+SELECT
+  row_number() OVER (ORDER BY id) AS position,
+  lag(name) OVER (ORDER BY id) AS prev,
+  sum(quantity) OVER (ORDER BY id) AS running_quantity
+FROM items
+`
+
+type WindowsRow struct {
+	Position        int64
+	Prev            sql.NullString
+	RunningQuantity sql.NullInt64
+}
+
+func (q *Queries) Windows(ctx context.Context) ([]WindowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, windows)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WindowsRow
+	for rows.Next() {
+		var i WindowsRow
+		if err := rows.Scan(&i.Position, &i.Prev, &i.RunningQuantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,64 @@
+CREATE TABLE items (
+  id       BIGSERIAL PRIMARY KEY,
+  name     text      NOT NULL,
+  nickname text,
+  price    numeric   NOT NULL,
+  quantity integer   NOT NULL,
+  discount integer,
+  tags     text[]    NOT NULL
+);
+
+-- name: Arithmetic :one
+SELECT quantity * 2 AS doubled, price * quantity AS total, quantity - discount AS remaining
+FROM items WHERE id = $1;
+
+-- name: Conditionals :one
+SELECT
+  CASE WHEN discount > 0 THEN 'sale' ELSE 'full' END AS label,
+  CASE WHEN discount > 0 THEN discount END AS sale,
+  COALESCE(discount, 0) AS discount,
+  NULLIF(name, '') AS name,
+  GREATEST(quantity, discount) AS most
+FROM items WHERE id = $1;
+
+-- name: Strings :one
+SELECT name || ' ' || nickname AS full_name, name || '!' AS shout, tags || name AS more_tags
+FROM items WHERE id = $1;
+
+-- name: Casts :one
+SELECT quantity::bigint AS big_quantity, discount::text AS discount_text
+FROM items WHERE id = $1;
+
+-- name: Predicates :one
+SELECT
+  quantity > 10 AS many,
+  discount IS NULL AS full_price,
+  name LIKE 'a%' AS starts_with_a,
+  quantity BETWEEN 1 AND 10 AS few,
+  discount IN (1, 2) AS small_discount
+FROM items WHERE id = $1;
+
+-- name: SoldOut :one
+SELECT EXISTS (SELECT 1 FROM items WHERE quantity = 0) AS sold_out;
+
+-- name: Aggregates :one
+SELECT count(*) AS items, sum(quantity) AS quantity, max(price) AS max_price
+FROM items;
+
+-- name: ListNicknames :many
+SELECT lower(nickname) AS nickname, upper(name) AS name FROM items;
+
+-- name: Windows :many
+SELECT
+  row_number() OVER (ORDER BY id) AS position,
+  lag(name) OVER (ORDER BY id) AS prev,
+  sum(quantity) OVER (ORDER BY id) AS running_quantity
+FROM items;
+
+-- name: Lookups :one
+SELECT
+  array_position(ARRAY[1, 2], 3) AS position,
+  array_position(tags, name) AS tag_position,
+  length(name) AS name_length,
+  md5(name) AS hash
+FROM items WHERE id = $1;
@@ -0,0 +1,17 @@
+CREATE TABLE items (
+  id       BIGINT  NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name     TEXT    NOT NULL,
+  price    DECIMAL NOT NULL,
+  quantity INT     NOT NULL,
+  discount INT
+);
+
+/* name: GetItemTotals :one */
+SELECT
+  quantity * 2 AS doubled,
+  price * quantity AS total,
+  quantity - discount AS remaining,
+  quantity > 10 AS many,
+  COALESCE(discount, 0) AS discount,
+  COUNT(*) AS items
+FROM items WHERE id = ?;
@@ -5,15 +5,16 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const makeIntervalDays = `-- name: MakeIntervalDays :one
 SELECT make_interval(days => $1::int)
 `
 
-func (q *Queries) MakeIntervalDays(ctx context.Context, dollar_1 int32) (int64, error) {
+func (q *Queries) MakeIntervalDays(ctx context.Context, dollar_1 int32) (sql.NullInt64, error) {
 	row := q.db.QueryRowContext(ctx, makeIntervalDays, dollar_1)
-	var make_interval int64
+	var make_interval sql.NullInt64
 	err := row.Scan(&make_interval)
 	return make_interval, err
 }
@@ -22,9 +23,9 @@ const makeIntervalMonths = `-- name: MakeIntervalMonths :one
 SELECT make_interval(months => $1::int)
 `
 
-func (q *Queries) MakeIntervalMonths(ctx context.Context, months int32) (int64, error) {
+func (q *Queries) MakeIntervalMonths(ctx context.Context, months int32) (sql.NullInt64, error) {
 	row := q.db.QueryRowContext(ctx, makeIntervalMonths, months)
-	var make_interval int64
+	var make_interval sql.NullInt64
 	err := row.Scan(&make_interval)
 	return make_interval, err
 }
@@ -33,9 +34,9 @@ const makeIntervalSecs = `-- name: MakeIntervalSecs :one
 SELECT make_interval(secs => $1)
 `
 
-func (q *Queries) MakeIntervalSecs(ctx context.Context, secs float64) (int64, error) {
+func (q *Queries) MakeIntervalSecs(ctx context.Context, secs float64) (sql.NullInt64, error) {
 	row := q.db.QueryRowContext(ctx, makeIntervalSecs, secs)
-	var make_interval int64
+	var make_interval sql.NullInt64
 	err := row.Scan(&make_interval)
 	return make_interval, err
 }
@@ -49,9 +50,9 @@ type PlusParams struct {
 	B int32
 }
 
-func (q *Queries) Plus(ctx context.Context, arg PlusParams) (int32, error) {
+func (q *Queries) Plus(ctx context.Context, arg PlusParams) (sql.NullInt32, error) {
 	row := q.db.QueryRowContext(ctx, plus, arg.A, arg.B)
-	var plus int32
+	var plus sql.NullInt32
 	err := row.Scan(&plus)
 	return plus, err
 }
@@ -60,9 +61,9 @@ const tableArgs = `-- name: TableArgs :one
 SELECT table_args(x => $1)
 `
 
-func (q *Queries) TableArgs(ctx context.Context, x int32) (int32, error) {
+func (q *Queries) TableArgs(ctx context.Context, x int32) (sql.NullInt32, error) {
 	row := q.db.QueryRowContext(ctx, tableArgs, x)
-	var table_args int32
+	var table_args sql.NullInt32
 	err := row.Scan(&table_args)
 	return table_args, err
 }
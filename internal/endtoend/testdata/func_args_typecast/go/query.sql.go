@@ -5,6 +5,7 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const plusPositionalCast = `-- name: PlusPositionalCast :one
@@ -16,9 +17,9 @@ type PlusPositionalCastParams struct {
 	Column2 int32
 }
 
-func (q *Queries) PlusPositionalCast(ctx context.Context, arg PlusPositionalCastParams) (int32, error) {
+func (q *Queries) PlusPositionalCast(ctx context.Context, arg PlusPositionalCastParams) (sql.NullInt32, error) {
 	row := q.db.QueryRowContext(ctx, plusPositionalCast, arg.A, arg.Column2)
-	var plus int32
+	var plus sql.NullInt32
 	err := row.Scan(&plus)
 	return plus, err
 }
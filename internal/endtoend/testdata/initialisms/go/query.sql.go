@@ -0,0 +1,62 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getHTTPRequestByUUID = `-- name: GetHTTPRequestByUUID :one
+SELECT id, request_uuid, user_ksuid, target_url, json_body FROM http_requests WHERE request_uuid = $1
+`
+
+func (q *Queries) GetHTTPRequestByUUID(ctx context.Context, requestUUID string) (HTTPRequest, error) {
+	row := q.db.QueryRowContext(ctx, getHTTPRequestByUUID, requestUUID)
+	var i HTTPRequest
+	err := row.Scan(
+		&i.ID,
+		&i.RequestUUID,
+		&i.UserKSUID,
+		&i.TargetURL,
+		&i.JSONBody,
+	)
+	return i, err
+}
+
+const listHTTPRequestUrls = `-- name: ListHTTPRequestUrls :many
+SELECT id, target_url FROM http_requests WHERE user_ksuid = $1 AND id > $2
+`
+
+type ListHTTPRequestUrlsParams struct {
+	UserKSUID string
+	ID        int64
+}
+
+type ListHTTPRequestUrlsRow struct {
+	ID        int64
+	TargetURL string
+}
+
+func (q *Queries) ListHTTPRequestUrls(ctx context.Context, arg ListHTTPRequestUrlsParams) ([]ListHTTPRequestUrlsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listHTTPRequestUrls, arg.UserKSUID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHTTPRequestUrlsRow
+	for rows.Next() {
+		var i ListHTTPRequestUrlsRow
+		if err := rows.Scan(&i.ID, &i.TargetURL); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
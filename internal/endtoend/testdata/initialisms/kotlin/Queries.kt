@@ -0,0 +1,19 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+
+import sqlc.runtime.ListQuery
+import sqlc.runtime.RowQuery
+
+interface Queries {
+  @Throws(SQLException::class)
+  fun getHTTPRequestByUUID(requestUUID: String): RowQuery<HTTPRequest>
+  
+  @Throws(SQLException::class)
+  fun listHTTPRequestUrls(userKSUID: String, id: Long): ListQuery<ListHTTPRequestUrlsRow>
+  
+}
+
@@ -0,0 +1,78 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+
+import sqlc.runtime.ListQuery
+import sqlc.runtime.RowQuery
+
+const val getHTTPRequestByUUID = """-- name: getHTTPRequestByUUID :one
+SELECT id, request_uuid, user_ksuid, target_url, json_body FROM http_requests WHERE request_uuid = ?
+"""
+
+const val listHTTPRequestUrls = """-- name: listHTTPRequestUrls :many
+SELECT id, target_url FROM http_requests WHERE user_ksuid = ? AND id > ?
+"""
+
+data class ListHTTPRequestUrlsRow (
+  val id: Long,
+  val targetURL: String
+)
+
+class QueriesImpl(private val conn: Connection) : Queries {
+
+  @Throws(SQLException::class)
+  override fun getHTTPRequestByUUID(requestUUID: String): RowQuery<HTTPRequest> {
+    return object : RowQuery<HTTPRequest>() {
+      override fun execute(): HTTPRequest {
+        return conn.prepareStatement(getHTTPRequestByUUID).use { stmt ->
+          this.statement = stmt
+          stmt.setString(1, requestUUID)
+
+          val results = stmt.executeQuery()
+          if (!results.next()) {
+            throw SQLException("no rows in result set")
+          }
+          val ret = HTTPRequest(
+                results.getLong(1),
+                results.getString(2),
+                results.getString(3),
+                results.getString(4),
+                results.getString(5)
+            )
+          if (results.next()) {
+              throw SQLException("expected one row in result set, but got many")
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listHTTPRequestUrls(userKSUID: String, id: Long): ListQuery<ListHTTPRequestUrlsRow> {
+    return object : ListQuery<ListHTTPRequestUrlsRow>() {
+      override fun execute(): List<ListHTTPRequestUrlsRow> {
+        return conn.prepareStatement(listHTTPRequestUrls).use { stmt ->
+          this.statement = stmt
+          stmt.setString(1, userKSUID)
+          stmt.setLong(2, id)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<ListHTTPRequestUrlsRow>()
+          while (results.next()) {
+              ret.add(ListHTTPRequestUrlsRow(
+                results.getLong(1),
+                results.getString(2)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+}
+
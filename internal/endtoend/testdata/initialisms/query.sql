@@ -0,0 +1,13 @@
+CREATE TABLE http_requests (
+  id           BIGSERIAL PRIMARY KEY,
+  request_uuid text      NOT NULL,
+  user_ksuid   text      NOT NULL,
+  target_url   text      NOT NULL,
+  json_body    jsonb
+);
+
+-- name: GetHttpRequestByUuid :one
+SELECT * FROM http_requests WHERE request_uuid = $1;
+
+-- name: ListHttpRequestUrls :many
+SELECT id, target_url FROM http_requests WHERE user_ksuid = $1 AND id > $2;
@@ -0,0 +1,10 @@
+CREATE TABLE foo (id BIGINT NOT NULL, name TEXT NOT NULL, tags TEXT[] NOT NULL);
+
+-- name: ColumnCount :many
+SELECT id, name FROM foo UNION SELECT id FROM foo;
+
+-- name: MismatchedTypes :many
+SELECT id FROM foo INTERSECT SELECT name FROM foo;
+
+-- name: MismatchedArrays :many
+SELECT tags FROM foo EXCEPT SELECT name FROM foo;
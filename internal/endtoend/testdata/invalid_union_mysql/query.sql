@@ -0,0 +1,7 @@
+CREATE TABLE foo (id BIGINT NOT NULL, name TEXT NOT NULL);
+
+/* name: ColumnCount :many */
+SELECT id, name FROM foo UNION SELECT id FROM foo;
+
+/* name: IntersectFirst :many */
+SELECT id FROM foo EXCEPT SELECT id, name FROM foo INTERSECT SELECT id FROM foo;
@@ -0,0 +1,367 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const chainedJoin = `-- name: ChainedJoin :many
+SELECT a.name, b.title, r.rating
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+JOIN reviews r ON r.book_id = b.id
+`
+
+type ChainedJoinRow struct {
+	Name   string
+	Title  sql.NullString
+	Rating int32
+}
+
+func (q *Queries) ChainedJoin(ctx context.Context) ([]ChainedJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, chainedJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ChainedJoinRow
+	for rows.Next() {
+		var i ChainedJoinRow
+		if err := rows.Scan(&i.Name, &i.Title, &i.Rating); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const fullJoin = `-- name: FullJoin :many
+SELECT authors.name, books.title
+FROM authors
+FULL JOIN books ON books.author_id = authors.id
+`
+
+type FullJoinRow struct {
+	Name  sql.NullString
+	Title sql.NullString
+}
+
+func (q *Queries) FullJoin(ctx context.Context) ([]FullJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, fullJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FullJoinRow
+	for rows.Next() {
+		var i FullJoinRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const innerJoin = `-- name: InnerJoin :many
+SELECT authors.name, books.title
+FROM authors
+JOIN books ON books.author_id = authors.id
+`
+
+type InnerJoinRow struct {
+	Name  string
+	Title string
+}
+
+func (q *Queries) InnerJoin(ctx context.Context) ([]InnerJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, innerJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []InnerJoinRow
+	for rows.Next() {
+		var i InnerJoinRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const joinInCTE = `-- name: JoinInCTE :many
+WITH author_books AS (
+  SELECT authors.name, books.title
+  FROM authors
+  LEFT JOIN books ON books.author_id = authors.id
+)
+SELECT name, title FROM author_books
+`
+
+type JoinInCTERow struct {
+	Name  string
+	Title sql.NullString
+}
+
+func (q *Queries) JoinInCTE(ctx context.Context) ([]JoinInCTERow, error) {
+	rows, err := q.db.QueryContext(ctx, joinInCTE)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JoinInCTERow
+	for rows.Next() {
+		var i JoinInCTERow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const joinInSubquery = `-- name: JoinInSubquery :many
+SELECT ab.name, ab.title
+FROM (
+  SELECT authors.name, books.title
+  FROM authors
+  LEFT JOIN books ON books.author_id = authors.id
+) ab
+`
+
+type JoinInSubqueryRow struct {
+	Name  string
+	Title sql.NullString
+}
+
+func (q *Queries) JoinInSubquery(ctx context.Context) ([]JoinInSubqueryRow, error) {
+	rows, err := q.db.QueryContext(ctx, joinInSubquery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []JoinInSubqueryRow
+	for rows.Next() {
+		var i JoinInSubqueryRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leftJoin = `-- name: LeftJoin :many
+SELECT authors.name, books.title
+FROM authors
+LEFT JOIN books ON books.author_id = authors.id
+`
+
+type LeftJoinRow struct {
+	Name  string
+	Title sql.NullString
+}
+
+func (q *Queries) LeftJoin(ctx context.Context) ([]LeftJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, leftJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LeftJoinRow
+	for rows.Next() {
+		var i LeftJoinRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leftJoinStar = `-- name: LeftJoinStar :many
+SELECT books.id, author_id, title, authors.id, name
+FROM books
+LEFT JOIN authors ON authors.id = books.author_id
+`
+
+type LeftJoinStarRow struct {
+	ID       int32
+	AuthorID int32
+	Title    string
+	ID_2     sql.NullInt32
+	Name     sql.NullString
+}
+
+func (q *Queries) LeftJoinStar(ctx context.Context) ([]LeftJoinStarRow, error) {
+	rows, err := q.db.QueryContext(ctx, leftJoinStar)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LeftJoinStarRow
+	for rows.Next() {
+		var i LeftJoinStarRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Title,
+			&i.ID_2,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leftJoinSubquery = `-- name: LeftJoinSubquery :many
+SELECT authors.name, b.title
+FROM authors
+LEFT JOIN (SELECT author_id, title FROM books) b ON b.author_id = authors.id
+`
+
+type LeftJoinSubqueryRow struct {
+	Name  string
+	Title sql.NullString
+}
+
+func (q *Queries) LeftJoinSubquery(ctx context.Context) ([]LeftJoinSubqueryRow, error) {
+	rows, err := q.db.QueryContext(ctx, leftJoinSubquery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LeftJoinSubqueryRow
+	for rows.Next() {
+		var i LeftJoinSubqueryRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const nestedJoin = `-- name: NestedJoin :many
+SELECT authors.name, books.title, reviews.rating
+FROM authors
+LEFT JOIN (books JOIN reviews ON reviews.book_id = books.id) ON books.author_id = authors.id
+`
+
+type NestedJoinRow struct {
+	Name   string
+	Title  sql.NullString
+	Rating sql.NullInt32
+}
+
+func (q *Queries) NestedJoin(ctx context.Context) ([]NestedJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, nestedJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NestedJoinRow
+	for rows.Next() {
+		var i NestedJoinRow
+		if err := rows.Scan(&i.Name, &i.Title, &i.Rating); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rightJoin = `-- name: RightJoin :many
+SELECT authors.name, books.title
+FROM authors
+RIGHT JOIN books ON books.author_id = authors.id
+`
+
+type RightJoinRow struct {
+	Name  sql.NullString
+	Title string
+}
+
+func (q *Queries) RightJoin(ctx context.Context) ([]RightJoinRow, error) {
+	rows, err := q.db.QueryContext(ctx, rightJoin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RightJoinRow
+	for rows.Next() {
+		var i RightJoinRow
+		if err := rows.Scan(&i.Name, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
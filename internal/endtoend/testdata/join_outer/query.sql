@@ -0,0 +1,73 @@
+CREATE TABLE authors (
+  id   SERIAL PRIMARY KEY,
+  name text   NOT NULL
+);
+
+CREATE TABLE books (
+  id        SERIAL  PRIMARY KEY,
+  author_id integer NOT NULL,
+  title     text    NOT NULL
+);
+
+CREATE TABLE reviews (
+  id      SERIAL  PRIMARY KEY,
+  book_id integer NOT NULL,
+  rating  integer NOT NULL
+);
+
+-- name: LeftJoin :many
+SELECT authors.name, books.title
+FROM authors
+LEFT JOIN books ON books.author_id = authors.id;
+
+-- name: RightJoin :many
+SELECT authors.name, books.title
+FROM authors
+RIGHT JOIN books ON books.author_id = authors.id;
+
+-- name: FullJoin :many
+SELECT authors.name, books.title
+FROM authors
+FULL JOIN books ON books.author_id = authors.id;
+
+-- name: InnerJoin :many
+SELECT authors.name, books.title
+FROM authors
+JOIN books ON books.author_id = authors.id;
+
+-- name: NestedJoin :many
+SELECT authors.name, books.title, reviews.rating
+FROM authors
+LEFT JOIN (books JOIN reviews ON reviews.book_id = books.id) ON books.author_id = authors.id;
+
+-- name: ChainedJoin :many
+SELECT a.name, b.title, r.rating
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+JOIN reviews r ON r.book_id = b.id;
+
+-- name: LeftJoinStar :many
+SELECT *
+FROM books
+LEFT JOIN authors ON authors.id = books.author_id;
+
+-- name: LeftJoinSubquery :many
+SELECT authors.name, b.title
+FROM authors
+LEFT JOIN (SELECT author_id, title FROM books) b ON b.author_id = authors.id;
+
+-- name: JoinInSubquery :many
+SELECT ab.name, ab.title
+FROM (
+  SELECT authors.name, books.title
+  FROM authors
+  LEFT JOIN books ON books.author_id = authors.id
+) ab;
+
+-- name: JoinInCTE :many
+WITH author_books AS (
+  SELECT authors.name, books.title
+  FROM authors
+  LEFT JOIN books ON books.author_id = authors.id
+)
+SELECT name, title FROM author_books;
@@ -0,0 +1,7 @@
+-- name: GetAuthor :one
+-- Fetch a single author
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: UpdateMood :exec
+UPDATE authors SET mood = sqlc.arg(mood) WHERE id = sqlc.arg(id);
@@ -0,0 +1,17 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad');
+
+CREATE TYPE point3 AS (x int, y int, z int);
+
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text,
+  mood mood
+);
+
+COMMENT ON TABLE authors IS 'People who write books';
+COMMENT ON COLUMN authors.bio IS 'A short biography';
+
+CREATE FUNCTION author_count(min_id bigint, max_id bigint DEFAULT NULL) RETURNS bigint AS $$
+  SELECT count(*) FROM authors WHERE id >= min_id
+$$ LANGUAGE sql;
@@ -0,0 +1,301 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const addAuthorTag = `-- name: AddAuthorTag :exec
+UPDATE authors SET tags = array_append(tags, $1) WHERE id = $2
+`
+
+type AddAuthorTagParams struct {
+	ArrayAppend string
+	ID          int64
+}
+
+func (q *Queries) AddAuthorTag(ctx context.Context, arg AddAuthorTagParams) error {
+	_, err := q.db.ExecContext(ctx, addAuthorTag, arg.ArrayAppend, arg.ID)
+	return err
+}
+
+const listAuthorsBetween = `-- name: ListAuthorsBetween :many
+SELECT id FROM authors WHERE created_at BETWEEN $1 AND $2
+`
+
+type ListAuthorsBetweenParams struct {
+	CreatedAt   time.Time
+	CreatedAt_2 time.Time
+}
+
+func (q *Queries) ListAuthorsBetween(ctx context.Context, arg ListAuthorsBetweenParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsBetween, arg.CreatedAt, arg.CreatedAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByBio = `-- name: ListAuthorsByBio :many
+SELECT id FROM authors WHERE COALESCE(bio, $1) = $2
+`
+
+type ListAuthorsByBioParams struct {
+	Bio   sql.NullString
+	Bio_2 string
+}
+
+func (q *Queries) ListAuthorsByBio(ctx context.Context, arg ListAuthorsByBioParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByBio, arg.Bio, arg.Bio_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByData = `-- name: ListAuthorsByData :many
+SELECT id FROM authors WHERE data ->> $1 = $2 AND data @> $3
+`
+
+type ListAuthorsByDataParams struct {
+	Column1 string
+	Data    sql.NullString
+	Data_2  json.RawMessage
+}
+
+func (q *Queries) ListAuthorsByData(ctx context.Context, arg ListAuthorsByDataParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByData, arg.Column1, arg.Data, arg.Data_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByIDs = `-- name: ListAuthorsByIDs :many
+SELECT id FROM authors WHERE id = ANY($1)
+`
+
+func (q *Queries) ListAuthorsByIDs(ctx context.Context, id []int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByIDs, pq.Array(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByName = `-- name: ListAuthorsByName :many
+SELECT id FROM authors WHERE lower(name) = lower($1)
+`
+
+func (q *Queries) ListAuthorsByName(ctx context.Context, lower string) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsByName, lower)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsCreatedWithin = `-- name: ListAuthorsCreatedWithin :many
+SELECT id FROM authors WHERE created_at + $1 > now()
+`
+
+func (q *Queries) ListAuthorsCreatedWithin(ctx context.Context, createdAt int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsCreatedWithin, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsMaybeTagged = `-- name: ListAuthorsMaybeTagged :many
+SELECT id, CASE WHEN $1 THEN tags ELSE $2 END AS tags FROM authors
+`
+
+type ListAuthorsMaybeTaggedParams struct {
+	Column1 bool
+	Tags    []string
+}
+
+type ListAuthorsMaybeTaggedRow struct {
+	ID   int64
+	Tags []string
+}
+
+func (q *Queries) ListAuthorsMaybeTagged(ctx context.Context, arg ListAuthorsMaybeTaggedParams) ([]ListAuthorsMaybeTaggedRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsMaybeTagged, arg.Column1, pq.Array(arg.Tags))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsMaybeTaggedRow
+	for rows.Next() {
+		var i ListAuthorsMaybeTaggedRow
+		if err := rows.Scan(&i.ID, pq.Array(&i.Tags)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsReversed = `-- name: ListAuthorsReversed :many
+SELECT id FROM authors WHERE $1 = name
+`
+
+func (q *Queries) ListAuthorsReversed(ctx context.Context, name string) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsReversed, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsWithBooks = `-- name: ListAuthorsWithBooks :many
+SELECT id FROM authors WHERE $1 IN (SELECT author_id FROM books WHERE pages > $2)
+`
+
+type ListAuthorsWithBooksParams struct {
+	AuthorID int64
+	Pages    int32
+}
+
+func (q *Queries) ListAuthorsWithBooks(ctx context.Context, arg ListAuthorsWithBooksParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsWithBooks, arg.AuthorID, arg.Pages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,44 @@
+CREATE TABLE authors (
+  id         BIGSERIAL   PRIMARY KEY,
+  name       text        NOT NULL,
+  bio        text,
+  tags       text[]      NOT NULL,
+  data       jsonb       NOT NULL,
+  created_at timestamp   NOT NULL
+);
+
+CREATE TABLE books (
+  id        BIGSERIAL PRIMARY KEY,
+  author_id bigint    NOT NULL,
+  pages     integer   NOT NULL
+);
+
+-- name: ListAuthorsByIDs :many
+SELECT id FROM authors WHERE id = ANY($1);
+
+-- name: ListAuthorsBetween :many
+SELECT id FROM authors WHERE created_at BETWEEN $1 AND $2;
+
+-- name: ListAuthorsByName :many
+SELECT id FROM authors WHERE lower(name) = lower($1);
+
+-- name: ListAuthorsReversed :many
+SELECT id FROM authors WHERE $1 = name;
+
+-- name: ListAuthorsWithBooks :many
+SELECT id FROM authors WHERE $1 IN (SELECT author_id FROM books WHERE pages > $2);
+
+-- name: ListAuthorsCreatedWithin :many
+SELECT id FROM authors WHERE created_at + $1 > now();
+
+-- name: ListAuthorsByData :many
+SELECT id FROM authors WHERE data ->> $1 = $2 AND data @> $3;
+
+-- name: ListAuthorsByBio :many
+SELECT id FROM authors WHERE COALESCE(bio, $1) = $2;
+
+-- name: ListAuthorsMaybeTagged :many
+SELECT id, CASE WHEN $1 THEN tags ELSE $2 END AS tags FROM authors;
+
+-- name: AddAuthorTag :exec
+UPDATE authors SET tags = array_append(tags, $1) WHERE id = $2;
@@ -5,21 +5,22 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const advisoryLock = `-- name: AdvisoryLock :many
 SELECT pg_advisory_unlock($1)
 `
 
-func (q *Queries) AdvisoryLock(ctx context.Context, pgAdvisoryUnlock int64) ([]bool, error) {
+func (q *Queries) AdvisoryLock(ctx context.Context, pgAdvisoryUnlock int64) ([]sql.NullBool, error) {
 	rows, err := q.db.QueryContext(ctx, advisoryLock, pgAdvisoryUnlock)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []bool
+	var items []sql.NullBool
 	for rows.Next() {
-		var pg_advisory_unlock bool
+		var pg_advisory_unlock sql.NullBool
 		if err := rows.Scan(&pg_advisory_unlock); err != nil {
 			return nil, err
 		}
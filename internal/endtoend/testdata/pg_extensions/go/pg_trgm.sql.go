@@ -5,15 +5,16 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const wordSimilarity = `-- name: WordSimilarity :one
 SELECT word_similarity('word', 'two words')
 `
 
-func (q *Queries) WordSimilarity(ctx context.Context) (float32, error) {
+func (q *Queries) WordSimilarity(ctx context.Context) (sql.NullFloat64, error) {
 	row := q.db.QueryRowContext(ctx, wordSimilarity)
-	var word_similarity float32
+	var word_similarity sql.NullFloat64
 	err := row.Scan(&word_similarity)
 	return word_similarity, err
 }
@@ -5,15 +5,16 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const encodeDigest = `-- name: EncodeDigest :one
 SELECT encode(digest($1, 'sha1'), 'hex')
 `
 
-func (q *Queries) EncodeDigest(ctx context.Context, digest string) (string, error) {
+func (q *Queries) EncodeDigest(ctx context.Context, digest string) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, encodeDigest, digest)
-	var encode string
+	var encode sql.NullString
 	err := row.Scan(&encode)
 	return encode, err
 }
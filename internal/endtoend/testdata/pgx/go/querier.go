@@ -0,0 +1,21 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+)
+
+type Querier interface {
+	CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error)
+	DeleteAuthor(ctx context.Context, id int64) error
+	DeleteAuthors(ctx context.Context, name string) (int64, error)
+	GetAuthor(ctx context.Context, id int64) (Author, error)
+	ListAuthors(ctx context.Context) ([]Author, error)
+	ListAuthorsByTags(ctx context.Context, dollar_1 []string) ([]ListAuthorsByTagsRow, error)
+	UpdateAuthorName(ctx context.Context, arg UpdateAuthorNameParams) (pgconn.CommandTag, error)
+}
+
+var _ Querier = (*Queries)(nil)
@@ -0,0 +1,162 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype"
+)
+
+const createAuthor = `-- name: CreateAuthor :one
+INSERT INTO authors (
+  name, tags, balance
+) VALUES (
+  $1, $2, $3
+)
+RETURNING id, name, tags, balance, lease, address, network
+`
+
+type CreateAuthorParams struct {
+	Name    string
+	Tags    []string
+	Balance pgtype.Numeric
+}
+
+func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	row := q.db.QueryRow(ctx, createAuthor, arg.Name, arg.Tags, arg.Balance)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Tags,
+		&i.Balance,
+		&i.Lease,
+		&i.Address,
+		&i.Network,
+	)
+	return i, err
+}
+
+const deleteAuthor = `-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, deleteAuthor, id)
+	return err
+}
+
+const deleteAuthors = `-- name: DeleteAuthors :execrows
+DELETE FROM authors
+WHERE name = $1
+`
+
+func (q *Queries) DeleteAuthors(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuthors, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, tags, balance, lease, address, network FROM authors
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRow(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Tags,
+		&i.Balance,
+		&i.Lease,
+		&i.Address,
+		&i.Network,
+	)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, tags, balance, lease, address, network FROM authors
+ORDER BY name
+`
+
+func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
+	rows, err := q.db.Query(ctx, listAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Tags,
+			&i.Balance,
+			&i.Lease,
+			&i.Address,
+			&i.Network,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorsByTags = `-- name: ListAuthorsByTags :many
+SELECT id, name FROM authors
+WHERE tags && $1::text[]
+`
+
+type ListAuthorsByTagsRow struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) ListAuthorsByTags(ctx context.Context, dollar_1 []string) ([]ListAuthorsByTagsRow, error) {
+	rows, err := q.db.Query(ctx, listAuthorsByTags, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorsByTagsRow
+	for rows.Next() {
+		var i ListAuthorsByTagsRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAuthorName = `-- name: UpdateAuthorName :execresult
+UPDATE authors
+SET name = $2
+WHERE id = $1
+`
+
+type UpdateAuthorNameParams struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) UpdateAuthorName(ctx context.Context, arg UpdateAuthorNameParams) (pgconn.CommandTag, error) {
+	return q.db.Exec(ctx, updateAuthorName, arg.ID, arg.Name)
+}
@@ -0,0 +1,42 @@
+CREATE TABLE authors (
+  id       BIGSERIAL PRIMARY KEY,
+  name     text      NOT NULL,
+  tags     text[]    NOT NULL,
+  balance  numeric   NOT NULL,
+  lease    interval,
+  address  inet,
+  network  cidr
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: ListAuthorsByTags :many
+SELECT id, name FROM authors
+WHERE tags && $1::text[];
+
+-- name: CreateAuthor :one
+INSERT INTO authors (
+  name, tags, balance
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: DeleteAuthors :execrows
+DELETE FROM authors
+WHERE name = $1;
+
+-- name: UpdateAuthorName :execresult
+UPDATE authors
+SET name = $2
+WHERE id = $1;
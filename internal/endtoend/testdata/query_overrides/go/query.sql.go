@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/example/pagination"
+)
+
+const countBooks = `-- name: CountBooks :one
+SELECT count(*) AS total FROM books
+`
+
+func (q *Queries) CountBooks(ctx context.Context) (int, error) {
+	row := q.db.QueryRowContext(ctx, countBooks)
+	var total int
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1
+`
+
+type AuthorDetails struct {
+	ID   int64
+	Name string
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (AuthorDetails, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i AuthorDetails
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT a.id, a.name, count(b.id) AS book_count
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+WHERE a.id > $1
+GROUP BY a.id, a.name
+LIMIT $2
+`
+
+type AuthorPage struct {
+	Cursor   pagination.Cursor
+	PageSize int32
+}
+
+type AuthorSummary struct {
+	ID        int64
+	Name      string
+	BookCount int32
+}
+
+func (q *Queries) ListAuthors(ctx context.Context, arg AuthorPage) ([]AuthorSummary, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors, arg.Cursor, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthorSummary
+	for rows.Next() {
+		var i AuthorSummary
+		if err := rows.Scan(&i.ID, &i.Name, &i.BookCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
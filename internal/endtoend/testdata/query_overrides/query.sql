@@ -0,0 +1,23 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL
+);
+
+CREATE TABLE books (
+  id        BIGSERIAL PRIMARY KEY,
+  author_id bigint    NOT NULL REFERENCES authors(id)
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- name: CountBooks :one
+SELECT count(*) AS total FROM books;
+
+-- name: ListAuthors :many
+SELECT a.id, a.name, count(b.id) AS book_count
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+WHERE a.id > sqlc.arg(cursor)
+GROUP BY a.id, a.name
+LIMIT sqlc.arg(page_size);
@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const fetchUser = `-- name: FetchUser :one
+SELECT id, avatar_url, homepage_url, payment_api_key, status FROM tbl_users WHERE id = $1
+`
+
+func (q *Queries) FetchUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, fetchUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.AvatarURL,
+		&i.Website,
+		&i.PaymentAPIKey,
+		&i.Status,
+	)
+	return i, err
+}
+
+const listUserAvatars = `-- name: ListUserAvatars :many
+SELECT id, avatar_url FROM tbl_users WHERE status = $1
+`
+
+type ListUserAvatarsRow struct {
+	ID        int64
+	AvatarURL string
+}
+
+func (q *Queries) ListUserAvatars(ctx context.Context, status Status) ([]ListUserAvatarsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserAvatars, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserAvatarsRow
+	for rows.Next() {
+		var i ListUserAvatarsRow
+		if err := rows.Scan(&i.ID, &i.AvatarURL); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,15 @@
+CREATE TYPE tbl_status AS ENUM ('active', 'inactive');
+
+CREATE TABLE tbl_users (
+  id              BIGSERIAL  PRIMARY KEY,
+  avatar_url      text       NOT NULL,
+  homepage_url    text,
+  payment_api_key text,
+  status          tbl_status NOT NULL
+);
+
+-- name: GetUser :one
+SELECT * FROM tbl_users WHERE id = $1;
+
+-- name: ListUserAvatars :many
+SELECT id, avatar_url FROM tbl_users WHERE status = $1;
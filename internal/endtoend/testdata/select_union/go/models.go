@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "draft"
+	PostStatusPublished PostStatus = "published"
+)
+
+func (e *PostStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PostStatus(s)
+	case string:
+		*e = PostStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PostStatus: %T", src)
+	}
+	return nil
+}
+
+type Comment struct {
+	ID        int32
+	PostID    int64
+	Body      string
+	Likes     int32
+	CreatedAt time.Time
+}
+
+type Post struct {
+	ID        int64
+	AuthorID  int32
+	Title     string
+	Body      sql.NullString
+	Score     string
+	CreatedAt time.Time
+	Status    PostStatus
+}
@@ -0,0 +1,317 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const feed = `-- name: Feed :many
+SELECT id, title AS text, score, created_at FROM posts WHERE author_id = $1
+UNION ALL
+SELECT id, body, likes, created_at FROM comments WHERE post_id = $2
+ORDER BY created_at DESC
+LIMIT $3
+`
+
+type FeedParams struct {
+	AuthorID int32
+	PostID   int64
+	Limit    int32
+}
+
+type FeedRow struct {
+	ID        int64
+	Text      string
+	Score     string
+	CreatedAt time.Time
+}
+
+func (q *Queries) Feed(ctx context.Context, arg FeedParams) ([]FeedRow, error) {
+	rows, err := q.db.QueryContext(ctx, feed, arg.AuthorID, arg.PostID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedRow
+	for rows.Next() {
+		var i FeedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Text,
+			&i.Score,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActivity = `-- name: ListActivity :many
+SELECT id, 'post' AS kind, NULL AS post_id FROM posts WHERE id = $1
+UNION ALL
+SELECT id, 'comment', post_id FROM comments WHERE post_id = $1
+UNION ALL
+SELECT id, 'reply', post_id FROM comments WHERE id = $2
+`
+
+type ListActivityParams struct {
+	ID   int64
+	ID_2 int32
+}
+
+type ListActivityRow struct {
+	ID     int64
+	Kind   string
+	PostID sql.NullInt64
+}
+
+func (q *Queries) ListActivity(ctx context.Context, arg ListActivityParams) ([]ListActivityRow, error) {
+	rows, err := q.db.QueryContext(ctx, listActivity, arg.ID, arg.ID_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListActivityRow
+	for rows.Next() {
+		var i ListActivityRow
+		if err := rows.Scan(&i.ID, &i.Kind, &i.PostID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBodies = `-- name: ListBodies :many
+SELECT body FROM posts
+UNION
+SELECT body FROM comments
+`
+
+func (q *Queries) ListBodies(ctx context.Context) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listBodies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullString
+	for rows.Next() {
+		var body sql.NullString
+		if err := rows.Scan(&body); err != nil {
+			return nil, err
+		}
+		items = append(items, body)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommentedPosts = `-- name: ListCommentedPosts :many
+SELECT id FROM posts
+INTERSECT
+SELECT post_id FROM comments WHERE likes > $1
+`
+
+func (q *Queries) ListCommentedPosts(ctx context.Context, likes int32) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listCommentedPosts, likes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDates = `-- name: ListDates :many
+SELECT created_at FROM posts
+UNION
+SELECT '2020-01-01'
+`
+
+func (q *Queries) ListDates(ctx context.Context) ([]time.Time, error) {
+	rows, err := q.db.QueryContext(ctx, listDates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []time.Time
+	for rows.Next() {
+		var created_at time.Time
+		if err := rows.Scan(&created_at); err != nil {
+			return nil, err
+		}
+		items = append(items, created_at)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScores = `-- name: ListScores :many
+SELECT score FROM posts
+UNION ALL
+SELECT NULL
+`
+
+func (q *Queries) ListScores(ctx context.Context) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, listScores)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullString
+	for rows.Next() {
+		var score sql.NullString
+		if err := rows.Scan(&score); err != nil {
+			return nil, err
+		}
+		items = append(items, score)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatuses = `-- name: ListStatuses :many
+SELECT status FROM posts
+UNION
+SELECT 'archived'
+`
+
+func (q *Queries) ListStatuses(ctx context.Context) ([]PostStatus, error) {
+	rows, err := q.db.QueryContext(ctx, listStatuses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PostStatus
+	for rows.Next() {
+		var status PostStatus
+		if err := rows.Scan(&status); err != nil {
+			return nil, err
+		}
+		items = append(items, status)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUncommentedPosts = `-- name: ListUncommentedPosts :many
+SELECT id FROM posts
+EXCEPT ALL
+SELECT post_id FROM comments
+`
+
+func (q *Queries) ListUncommentedPosts(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, listUncommentedPosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchPosts = `-- name: SearchPosts :many
+SELECT id, author_id, title, body, score, created_at, status FROM posts WHERE title LIKE $1
+UNION
+SELECT id, author_id, title, body, score, created_at, status FROM posts WHERE body LIKE $2
+`
+
+type SearchPostsParams struct {
+	Title string
+	Body  sql.NullString
+}
+
+func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, searchPosts, arg.Title, arg.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Title,
+			&i.Body,
+			&i.Score,
+			&i.CreatedAt,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
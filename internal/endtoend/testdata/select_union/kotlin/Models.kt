@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.time.LocalDateTime
+import java.time.OffsetDateTime
+
+enum class PostStatus(val value: String) {
+  DRAFT("draft"),
+  PUBLISHED("published");
+
+  companion object {
+    private val map = PostStatus.values().associateBy(PostStatus::value)
+    fun lookup(value: String) = map[value]
+  }
+}
+
+data class Comment (
+  val id: Int,
+  val postId: Long,
+  val body: String,
+  val likes: Int,
+  val createdAt: OffsetDateTime
+)
+
+data class Post (
+  val id: Long,
+  val authorId: Int,
+  val title: String,
+  val body: String?,
+  val score: java.math.BigDecimal,
+  val createdAt: LocalDateTime,
+  val status: PostStatus
+)
+
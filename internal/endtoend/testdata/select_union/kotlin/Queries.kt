@@ -0,0 +1,44 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+import java.time.LocalDateTime
+import java.time.OffsetDateTime
+
+import sqlc.runtime.ListQuery
+
+interface Queries {
+  @Throws(SQLException::class)
+  fun feed(
+      authorId: Int,
+      postId: Long,
+      limit: Int): ListQuery<FeedRow>
+  
+  @Throws(SQLException::class)
+  fun listActivity(id: Long, id_2: Int): ListQuery<ListActivityRow>
+  
+  @Throws(SQLException::class)
+  fun listBodies(): ListQuery<String?>
+  
+  @Throws(SQLException::class)
+  fun listCommentedPosts(likes: Int): ListQuery<Long>
+  
+  @Throws(SQLException::class)
+  fun listDates(): ListQuery<LocalDateTime>
+  
+  @Throws(SQLException::class)
+  fun listScores(): ListQuery<java.math.BigDecimal?>
+  
+  @Throws(SQLException::class)
+  fun listStatuses(): ListQuery<PostStatus>
+  
+  @Throws(SQLException::class)
+  fun listUncommentedPosts(): ListQuery<Long>
+  
+  @Throws(SQLException::class)
+  fun searchPosts(title: String, body: String?): ListQuery<Post>
+  
+}
+
@@ -0,0 +1,277 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+import java.time.LocalDateTime
+import java.time.OffsetDateTime
+
+import sqlc.runtime.ListQuery
+
+const val feed = """-- name: feed :many
+SELECT id, title AS text, score, created_at FROM posts WHERE author_id = ?
+UNION ALL
+SELECT id, body, likes, created_at FROM comments WHERE post_id = ?
+ORDER BY created_at DESC
+LIMIT ?
+"""
+
+data class FeedRow (
+  val id: Long,
+  val text: String,
+  val score: java.math.BigDecimal,
+  val createdAt: OffsetDateTime
+)
+
+const val listActivity = """-- name: listActivity :many
+SELECT id, 'post' AS kind, NULL AS post_id FROM posts WHERE id = ?
+UNION ALL
+SELECT id, 'comment', post_id FROM comments WHERE post_id = ?
+UNION ALL
+SELECT id, 'reply', post_id FROM comments WHERE id = ?
+"""
+
+data class ListActivityRow (
+  val id: Long,
+  val kind: String,
+  val postId: Long?
+)
+
+const val listBodies = """-- name: listBodies :many
+SELECT body FROM posts
+UNION
+SELECT body FROM comments
+"""
+
+const val listCommentedPosts = """-- name: listCommentedPosts :many
+SELECT id FROM posts
+INTERSECT
+SELECT post_id FROM comments WHERE likes > ?
+"""
+
+const val listDates = """-- name: listDates :many
+SELECT created_at FROM posts
+UNION
+SELECT '2020-01-01'
+"""
+
+const val listScores = """-- name: listScores :many
+SELECT score FROM posts
+UNION ALL
+SELECT NULL
+"""
+
+const val listStatuses = """-- name: listStatuses :many
+SELECT status FROM posts
+UNION
+SELECT 'archived'
+"""
+
+const val listUncommentedPosts = """-- name: listUncommentedPosts :many
+SELECT id FROM posts
+EXCEPT ALL
+SELECT post_id FROM comments
+"""
+
+const val searchPosts = """-- name: searchPosts :many
+SELECT id, author_id, title, body, score, created_at, status FROM posts WHERE title LIKE ?
+UNION
+SELECT id, author_id, title, body, score, created_at, status FROM posts WHERE body LIKE ?
+"""
+
+class QueriesImpl(private val conn: Connection) : Queries {
+
+  @Throws(SQLException::class)
+  override fun feed(
+      authorId: Int,
+      postId: Long,
+      limit: Int): ListQuery<FeedRow> {
+    return object : ListQuery<FeedRow>() {
+      override fun execute(): List<FeedRow> {
+        return conn.prepareStatement(feed).use { stmt ->
+          this.statement = stmt
+          stmt.setInt(1, authorId)
+          stmt.setLong(2, postId)
+          stmt.setInt(3, limit)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<FeedRow>()
+          while (results.next()) {
+              ret.add(FeedRow(
+                results.getLong(1),
+                results.getString(2),
+                results.getBigDecimal(3),
+                results.getObject(4, OffsetDateTime::class.java)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listActivity(id: Long, id_2: Int): ListQuery<ListActivityRow> {
+    return object : ListQuery<ListActivityRow>() {
+      override fun execute(): List<ListActivityRow> {
+        return conn.prepareStatement(listActivity).use { stmt ->
+          this.statement = stmt
+          stmt.setLong(1, id)
+          stmt.setLong(2, id)
+          stmt.setInt(3, id_2)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<ListActivityRow>()
+          while (results.next()) {
+              ret.add(ListActivityRow(
+                results.getLong(1),
+                results.getString(2),
+                results.getLong(3)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listBodies(): ListQuery<String?> {
+    return object : ListQuery<String?>() {
+      override fun execute(): List<String?> {
+        return conn.prepareStatement(listBodies).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<String?>()
+          while (results.next()) {
+              ret.add(results.getString(1))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listCommentedPosts(likes: Int): ListQuery<Long> {
+    return object : ListQuery<Long>() {
+      override fun execute(): List<Long> {
+        return conn.prepareStatement(listCommentedPosts).use { stmt ->
+          this.statement = stmt
+          stmt.setInt(1, likes)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<Long>()
+          while (results.next()) {
+              ret.add(results.getLong(1))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listDates(): ListQuery<LocalDateTime> {
+    return object : ListQuery<LocalDateTime>() {
+      override fun execute(): List<LocalDateTime> {
+        return conn.prepareStatement(listDates).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<LocalDateTime>()
+          while (results.next()) {
+              ret.add(results.getObject(1, LocalDateTime::class.java))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listScores(): ListQuery<java.math.BigDecimal?> {
+    return object : ListQuery<java.math.BigDecimal?>() {
+      override fun execute(): List<java.math.BigDecimal?> {
+        return conn.prepareStatement(listScores).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<java.math.BigDecimal?>()
+          while (results.next()) {
+              ret.add(results.getBigDecimal(1))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listStatuses(): ListQuery<PostStatus> {
+    return object : ListQuery<PostStatus>() {
+      override fun execute(): List<PostStatus> {
+        return conn.prepareStatement(listStatuses).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<PostStatus>()
+          while (results.next()) {
+              ret.add(PostStatus.lookup(results.getString(1))!!)
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listUncommentedPosts(): ListQuery<Long> {
+    return object : ListQuery<Long>() {
+      override fun execute(): List<Long> {
+        return conn.prepareStatement(listUncommentedPosts).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<Long>()
+          while (results.next()) {
+              ret.add(results.getLong(1))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun searchPosts(title: String, body: String?): ListQuery<Post> {
+    return object : ListQuery<Post>() {
+      override fun execute(): List<Post> {
+        return conn.prepareStatement(searchPosts).use { stmt ->
+          this.statement = stmt
+          stmt.setString(1, title)
+          stmt.setString(2, body)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<Post>()
+          while (results.next()) {
+              ret.add(Post(
+                results.getLong(1),
+                results.getInt(2),
+                results.getString(3),
+                results.getString(4),
+                results.getBigDecimal(5),
+                results.getObject(6, LocalDateTime::class.java),
+                PostStatus.lookup(results.getString(7))!!
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+}
+
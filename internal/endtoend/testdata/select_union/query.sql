@@ -0,0 +1,48 @@
+-- name: Feed :many
+SELECT id, title AS text, score, created_at FROM posts WHERE author_id = $1
+UNION ALL
+SELECT id, body, likes, created_at FROM comments WHERE post_id = $2
+ORDER BY created_at DESC
+LIMIT $3;
+
+-- name: SearchPosts :many
+SELECT * FROM posts WHERE title LIKE $1
+UNION
+SELECT * FROM posts WHERE body LIKE $2;
+
+-- name: ListBodies :many
+SELECT body FROM posts
+UNION
+SELECT body FROM comments;
+
+-- name: ListActivity :many
+SELECT id, 'post' AS kind, NULL AS post_id FROM posts WHERE id = $1
+UNION ALL
+SELECT id, 'comment', post_id FROM comments WHERE post_id = $1
+UNION ALL
+SELECT id, 'reply', post_id FROM comments WHERE id = $2;
+
+-- name: ListCommentedPosts :many
+SELECT id FROM posts
+INTERSECT
+SELECT post_id FROM comments WHERE likes > $1;
+
+-- name: ListUncommentedPosts :many
+SELECT id FROM posts
+EXCEPT ALL
+SELECT post_id FROM comments;
+
+-- name: ListStatuses :many
+SELECT status FROM posts
+UNION
+SELECT 'archived';
+
+-- name: ListDates :many
+SELECT created_at FROM posts
+UNION
+SELECT '2020-01-01';
+
+-- name: ListScores :many
+SELECT score FROM posts
+UNION ALL
+SELECT NULL;
@@ -0,0 +1,19 @@
+CREATE TYPE post_status AS ENUM ('draft', 'published');
+
+CREATE TABLE posts (
+    id         BIGSERIAL PRIMARY KEY,
+    author_id  INT NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    body       TEXT,
+    score      NUMERIC NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    status     post_status NOT NULL
+);
+
+CREATE TABLE comments (
+    id         SERIAL PRIMARY KEY,
+    post_id    BIGINT NOT NULL,
+    body       TEXT NOT NULL,
+    likes      INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
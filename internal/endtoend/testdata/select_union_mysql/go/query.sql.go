@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+)
+
+const feed = `-- name: Feed :many
+SELECT id, title AS text, created_at FROM posts WHERE author_id = ?
+UNION ALL
+SELECT id, body, created_at FROM comments WHERE post_id = ?
+ORDER BY created_at DESC
+LIMIT ?
+`
+
+type FeedParams struct {
+	AuthorID int32
+	PostID   int64
+	Limit    int32
+}
+
+type FeedRow struct {
+	ID        int64
+	Text      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) Feed(ctx context.Context, arg FeedParams) ([]FeedRow, error) {
+	rows, err := q.db.QueryContext(ctx, feed, arg.AuthorID, arg.PostID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FeedRow
+	for rows.Next() {
+		var i FeedRow
+		if err := rows.Scan(&i.ID, &i.Text, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchPosts = `-- name: SearchPosts :many
+SELECT id, author_id, title, body, created_at FROM posts WHERE author_id = ?
+UNION
+SELECT id, author_id, title, body, created_at FROM posts WHERE title = ?
+`
+
+type SearchPostsParams struct {
+	AuthorID int32
+	Title    string
+}
+
+func (q *Queries) SearchPosts(ctx context.Context, arg SearchPostsParams) ([]Post, error) {
+	rows, err := q.db.QueryContext(ctx, searchPosts, arg.AuthorID, arg.Title)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Post
+	for rows.Next() {
+		var i Post
+		if err := rows.Scan(
+			&i.ID,
+			&i.AuthorID,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,11 @@
+/* name: Feed :many */
+SELECT id, title AS text, created_at FROM posts WHERE author_id = ?
+UNION ALL
+SELECT id, body, created_at FROM comments WHERE post_id = ?
+ORDER BY created_at DESC
+LIMIT ?;
+
+/* name: SearchPosts :many */
+SELECT * FROM posts WHERE author_id = ?
+UNION
+SELECT * FROM posts WHERE title = ?;
@@ -0,0 +1,14 @@
+CREATE TABLE posts (
+    id         BIGINT PRIMARY KEY,
+    author_id  INT NOT NULL,
+    title      VARCHAR(255) NOT NULL,
+    body       TEXT,
+    created_at DATETIME NOT NULL
+);
+
+CREATE TABLE comments (
+    id         INT PRIMARY KEY,
+    post_id    BIGINT NOT NULL,
+    body       TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
@@ -5,15 +5,16 @@ package querytest
 
 import (
 	"context"
+	"database/sql"
 )
 
 const mixedNotation = `-- name: MixedNotation :one
 SELECT concat_lower_or_upper('Hello', 'World', uppercase => true)
 `
 
-func (q *Queries) MixedNotation(ctx context.Context) (string, error) {
+func (q *Queries) MixedNotation(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, mixedNotation)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
@@ -22,9 +23,9 @@ const namedAnyOrder = `-- name: NamedAnyOrder :one
 SELECT concat_lower_or_upper(a => 'Hello', b => 'World', uppercase => true)
 `
 
-func (q *Queries) NamedAnyOrder(ctx context.Context) (string, error) {
+func (q *Queries) NamedAnyOrder(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, namedAnyOrder)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
@@ -33,9 +34,9 @@ const namedNotation = `-- name: NamedNotation :one
 SELECT concat_lower_or_upper(a => 'Hello', b => 'World')
 `
 
-func (q *Queries) NamedNotation(ctx context.Context) (string, error) {
+func (q *Queries) NamedNotation(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, namedNotation)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
@@ -44,9 +45,9 @@ const namedOtherOrder = `-- name: NamedOtherOrder :one
 SELECT concat_lower_or_upper(a => 'Hello', uppercase => true, b => 'World')
 `
 
-func (q *Queries) NamedOtherOrder(ctx context.Context) (string, error) {
+func (q *Queries) NamedOtherOrder(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, namedOtherOrder)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
@@ -55,9 +56,9 @@ const positionalNoDefaault = `-- name: PositionalNoDefaault :one
 SELECT concat_lower_or_upper('Hello', 'World')
 `
 
-func (q *Queries) PositionalNoDefaault(ctx context.Context) (string, error) {
+func (q *Queries) PositionalNoDefaault(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, positionalNoDefaault)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
@@ -66,9 +67,9 @@ const positionalNotation = `-- name: PositionalNotation :one
 SELECT concat_lower_or_upper('Hello', 'World', true)
 `
 
-func (q *Queries) PositionalNotation(ctx context.Context) (string, error) {
+func (q *Queries) PositionalNotation(ctx context.Context) (sql.NullString, error) {
 	row := q.db.QueryRowContext(ctx, positionalNotation)
-	var concat_lower_or_upper string
+	var concat_lower_or_upper sql.NullString
 	err := row.Scan(&concat_lower_or_upper)
 	return concat_lower_or_upper, err
 }
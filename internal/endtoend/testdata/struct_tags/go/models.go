@@ -0,0 +1,14 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"database/sql"
+)
+
+type User struct {
+	ID        int64          `json:"id" validate:"required" yaml:"id"`
+	Email     string         `json:"email" validate:"required,email" yaml:"email"`
+	FirstName sql.NullString `json:"firstName,omitempty" yaml:"first_name"`
+	BirthDate sql.NullTime   `json:"birthDate,omitempty" yaml:"birth_date"`
+}
@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listUsersByAge = `-- name: ListUsersByAge :many
+SELECT id, email, date_part('year', age(birth_date))::int AS age_in_years
+FROM users
+`
+
+type ListUsersByAgeRow struct {
+	ID         int64         `json:"id" validate:"required" yaml:"id"`
+	Email      string        `json:"email" validate:"required,email" yaml:"email"`
+	AgeInYears sql.NullInt32 `json:"ageInYears,omitempty" validate:"gte=0" yaml:"age_in_years"`
+}
+
+func (q *Queries) ListUsersByAge(ctx context.Context) ([]ListUsersByAgeRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByAge)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersByAgeRow
+	for rows.Next() {
+		var i ListUsersByAgeRow
+		if err := rows.Scan(&i.ID, &i.Email, &i.AgeInYears); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,10 @@
+CREATE TABLE users (
+  id         BIGSERIAL PRIMARY KEY,
+  email      text      NOT NULL,
+  first_name text,
+  birth_date date
+);
+
+-- name: ListUsersByAge :many
+SELECT id, email, date_part('year', age(birth_date))::int AS age_in_years
+FROM users;
@@ -0,0 +1,121 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getBookTotals = `-- name: GetBookTotals :one
+SELECT id, name, bio, books, total FROM book_totals WHERE id = $1
+`
+
+func (q *Queries) GetBookTotals(ctx context.Context, id int64) (BookTotal, error) {
+	row := q.db.QueryRowContext(ctx, getBookTotals, id)
+	var i BookTotal
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Books,
+		&i.Total,
+	)
+	return i, err
+}
+
+const listAuthorNames = `-- name: ListAuthorNames :many
+SELECT author_id, author_name FROM author_names ORDER BY author_name
+`
+
+func (q *Queries) ListAuthorNames(ctx context.Context) ([]AuthorName, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthorName
+	for rows.Next() {
+		var i AuthorName
+		if err := rows.Scan(&i.AuthorID, &i.AuthorName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthorStats = `-- name: ListAuthorStats :many
+SELECT s.author_id, s.max_price, a.name
+FROM author_stats s
+JOIN authors a ON a.id = s.author_id
+`
+
+type ListAuthorStatsRow struct {
+	AuthorID int64
+	MaxPrice sql.NullString
+	Name     string
+}
+
+func (q *Queries) ListAuthorStats(ctx context.Context) ([]ListAuthorStatsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorStats)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorStatsRow
+	for rows.Next() {
+		var i ListAuthorStatsRow
+		if err := rows.Scan(&i.AuthorID, &i.MaxPrice, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceyBooks = `-- name: ListPriceyBooks :many
+SELECT id, title, price FROM pricey_books WHERE author_id = $1
+`
+
+type ListPriceyBooksRow struct {
+	ID    int64
+	Title string
+	Price string
+}
+
+func (q *Queries) ListPriceyBooks(ctx context.Context, authorID int64) ([]ListPriceyBooksRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPriceyBooks, authorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPriceyBooksRow
+	for rows.Next() {
+		var i ListPriceyBooksRow
+		if err := rows.Scan(&i.ID, &i.Title, &i.Price); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,42 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+data class Author (
+  val id: Long,
+  val name: String,
+  val bio: String?
+)
+
+data class AuthorName (
+  val authorId: Long,
+  val authorName: String
+)
+
+data class AuthorStat (
+  val authorId: Long,
+  val maxPrice: java.math.BigDecimal?
+)
+
+data class Book (
+  val id: Long,
+  val authorId: Long,
+  val title: String,
+  val price: java.math.BigDecimal
+)
+
+data class BookTotal (
+  val id: Long,
+  val name: String,
+  val bio: String?,
+  val books: Long,
+  val total: java.math.BigDecimal?
+)
+
+data class PriceyBook (
+  val id: Long,
+  val authorId: Long,
+  val title: String,
+  val price: java.math.BigDecimal
+)
+
@@ -0,0 +1,25 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+
+import sqlc.runtime.ListQuery
+import sqlc.runtime.RowQuery
+
+interface Queries {
+  @Throws(SQLException::class)
+  fun getBookTotals(id: Long): RowQuery<BookTotal>
+  
+  @Throws(SQLException::class)
+  fun listAuthorNames(): ListQuery<AuthorName>
+  
+  @Throws(SQLException::class)
+  fun listAuthorStats(): ListQuery<ListAuthorStatsRow>
+  
+  @Throws(SQLException::class)
+  fun listPriceyBooks(authorId: Long): ListQuery<ListPriceyBooksRow>
+  
+}
+
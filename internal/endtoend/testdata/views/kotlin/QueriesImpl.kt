@@ -0,0 +1,138 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package com.example.querytest
+
+import java.sql.Connection
+import java.sql.SQLException
+
+import sqlc.runtime.ListQuery
+import sqlc.runtime.RowQuery
+
+const val getBookTotals = """-- name: getBookTotals :one
+SELECT id, name, bio, books, total FROM book_totals WHERE id = ?
+"""
+
+const val listAuthorNames = """-- name: listAuthorNames :many
+SELECT author_id, author_name FROM author_names ORDER BY author_name
+"""
+
+const val listAuthorStats = """-- name: listAuthorStats :many
+SELECT s.author_id, s.max_price, a.name
+FROM author_stats s
+JOIN authors a ON a.id = s.author_id
+"""
+
+data class ListAuthorStatsRow (
+  val authorId: Long,
+  val maxPrice: java.math.BigDecimal?,
+  val name: String
+)
+
+const val listPriceyBooks = """-- name: listPriceyBooks :many
+SELECT id, title, price FROM pricey_books WHERE author_id = ?
+"""
+
+data class ListPriceyBooksRow (
+  val id: Long,
+  val title: String,
+  val price: java.math.BigDecimal
+)
+
+class QueriesImpl(private val conn: Connection) : Queries {
+
+  @Throws(SQLException::class)
+  override fun getBookTotals(id: Long): RowQuery<BookTotal> {
+    return object : RowQuery<BookTotal>() {
+      override fun execute(): BookTotal {
+        return conn.prepareStatement(getBookTotals).use { stmt ->
+          this.statement = stmt
+          stmt.setLong(1, id)
+
+          val results = stmt.executeQuery()
+          if (!results.next()) {
+            throw SQLException("no rows in result set")
+          }
+          val ret = BookTotal(
+                results.getLong(1),
+                results.getString(2),
+                results.getString(3),
+                results.getLong(4),
+                results.getBigDecimal(5)
+            )
+          if (results.next()) {
+              throw SQLException("expected one row in result set, but got many")
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listAuthorNames(): ListQuery<AuthorName> {
+    return object : ListQuery<AuthorName>() {
+      override fun execute(): List<AuthorName> {
+        return conn.prepareStatement(listAuthorNames).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<AuthorName>()
+          while (results.next()) {
+              ret.add(AuthorName(
+                results.getLong(1),
+                results.getString(2)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listAuthorStats(): ListQuery<ListAuthorStatsRow> {
+    return object : ListQuery<ListAuthorStatsRow>() {
+      override fun execute(): List<ListAuthorStatsRow> {
+        return conn.prepareStatement(listAuthorStats).use { stmt ->
+          this.statement = stmt
+          
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<ListAuthorStatsRow>()
+          while (results.next()) {
+              ret.add(ListAuthorStatsRow(
+                results.getLong(1),
+                results.getBigDecimal(2),
+                results.getString(3)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+  @Throws(SQLException::class)
+  override fun listPriceyBooks(authorId: Long): ListQuery<ListPriceyBooksRow> {
+    return object : ListQuery<ListPriceyBooksRow>() {
+      override fun execute(): List<ListPriceyBooksRow> {
+        return conn.prepareStatement(listPriceyBooks).use { stmt ->
+          this.statement = stmt
+          stmt.setLong(1, authorId)
+
+          val results = stmt.executeQuery()
+          val ret = mutableListOf<ListPriceyBooksRow>()
+          while (results.next()) {
+              ret.add(ListPriceyBooksRow(
+                results.getLong(1),
+                results.getString(2),
+                results.getBigDecimal(3)
+            ))
+          }
+          ret
+        }
+      }
+    }
+  }
+
+}
+
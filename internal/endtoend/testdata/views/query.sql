@@ -0,0 +1,13 @@
+-- name: ListAuthorNames :many
+SELECT * FROM author_names ORDER BY author_name;
+
+-- name: GetBookTotals :one
+SELECT * FROM book_totals WHERE id = $1;
+
+-- name: ListPriceyBooks :many
+SELECT id, title, price FROM pricey_books WHERE author_id = $1;
+
+-- name: ListAuthorStats :many
+SELECT s.author_id, s.max_price, a.name
+FROM author_stats s
+JOIN authors a ON a.id = s.author_id;
@@ -0,0 +1,37 @@
+CREATE TABLE authors (
+    id         BIGSERIAL PRIMARY KEY,
+    name       TEXT NOT NULL,
+    bio        TEXT
+);
+
+CREATE TABLE books (
+    id         BIGSERIAL PRIMARY KEY,
+    author_id  BIGINT NOT NULL REFERENCES authors (id),
+    title      TEXT NOT NULL,
+    price      NUMERIC NOT NULL
+);
+
+CREATE VIEW author_names (author_id, author_name) AS SELECT id, name FROM authors;
+
+CREATE VIEW book_totals AS
+SELECT a.id, a.name, count(b.id) AS books, sum(b.price) AS total
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+GROUP BY a.id, a.name;
+
+CREATE OR REPLACE VIEW book_totals AS
+SELECT a.id, a.name, a.bio, count(b.id) AS books, sum(b.price) AS total
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+GROUP BY a.id, a.name, a.bio;
+
+CREATE VIEW expensive_books AS SELECT * FROM books WHERE price > 100;
+
+ALTER VIEW expensive_books RENAME TO pricey_books;
+
+CREATE VIEW titles AS SELECT title FROM books;
+
+DROP VIEW titles;
+
+CREATE MATERIALIZED VIEW author_stats AS
+SELECT author_id, max(price) AS max_price FROM books GROUP BY author_id;
@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getAuthorWithBio = `-- name: GetAuthorWithBio :one
+SELECT id, name, bio FROM authors_with_bio WHERE id = ?
+`
+
+func (q *Queries) GetAuthorWithBio(ctx context.Context, id int64) (AuthorsWithBio, error) {
+	row := q.db.QueryRowContext(ctx, getAuthorWithBio, id)
+	var i AuthorsWithBio
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const listAuthorNames = `-- name: ListAuthorNames :many
+SELECT author_id, author_name FROM author_names ORDER BY author_name
+`
+
+func (q *Queries) ListAuthorNames(ctx context.Context) ([]AuthorName, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuthorName
+	for rows.Next() {
+		var i AuthorName
+		if err := rows.Scan(&i.AuthorID, &i.AuthorName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
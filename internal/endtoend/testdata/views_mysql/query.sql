@@ -0,0 +1,5 @@
+/* name: ListAuthorNames :many */
+SELECT * FROM author_names ORDER BY author_name;
+
+/* name: GetAuthorWithBio :one */
+SELECT * FROM authors_with_bio WHERE id = ?;
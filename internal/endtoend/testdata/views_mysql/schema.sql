@@ -0,0 +1,15 @@
+CREATE TABLE authors (
+    id   BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name TEXT NOT NULL,
+    bio  TEXT
+);
+
+CREATE VIEW author_names (author_id, author_name) AS SELECT id, name FROM authors;
+
+CREATE VIEW authors_with_bio AS SELECT id, name FROM authors WHERE bio IS NOT NULL;
+
+CREATE OR REPLACE VIEW authors_with_bio AS SELECT id, name, bio FROM authors WHERE bio IS NOT NULL;
+
+CREATE VIEW author_ids AS SELECT id FROM authors;
+
+DROP VIEW author_ids;
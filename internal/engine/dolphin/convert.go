@@ -3,6 +3,7 @@ package dolphin
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	pcast "github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/opcode"
@@ -109,17 +110,23 @@ func (c *cc) convertAssignment(n *pcast.Assignment) *ast.ResTarget {
 }
 
 func opToName(o opcode.Op) string {
-	switch o {
-	case opcode.EQ:
-		return "="
+	if o.IsKeyword() {
+		return o.String()
 	}
-	return o.String()
+	// Operators are named by their symbol, as they are in PostgreSQL
+	var name strings.Builder
+	o.Format(&name)
+	return name.String()
 }
 
 func (c *cc) convertBinaryOperationExpr(n *pcast.BinaryOperationExpr) ast.Node {
 	if n.Op == opcode.LogicAnd || n.Op == opcode.LogicOr {
+		op := ast.AND_EXPR
+		if n.Op == opcode.LogicOr {
+			op = ast.OR_EXPR
+		}
 		return &ast.BoolExpr{
-			// TODO: Set op
+			Boolop: op,
 			Args: &ast.List{
 				Items: []ast.Node{
 					c.convert(n.L),
@@ -172,6 +179,7 @@ func (c *cc) convertCreateTableStmt(n *pcast.CreateTableStmt) ast.Node {
 			Colname:   def.Name.String(),
 			TypeName:  &ast.TypeName{Name: types.TypeStr(def.Tp.Tp)},
 			IsNotNull: isNotNull(def),
+			IsUnique:  isUnique(def),
 			Comment:   comment,
 			Vals:      vals,
 		})
@@ -186,11 +194,14 @@ func (c *cc) convertCreateTableStmt(n *pcast.CreateTableStmt) ast.Node {
 }
 
 func (c *cc) convertColumnNameExpr(n *pcast.ColumnNameExpr) *ast.ColumnRef {
+	var items []ast.Node
+	if table := n.Name.Table.String(); table != "" {
+		items = append(items, &ast.String{Str: table})
+	}
+	items = append(items, &ast.String{Str: n.Name.Name.String()})
 	return &ast.ColumnRef{
 		Fields: &ast.List{
-			Items: []ast.Node{
-				&ast.String{Str: n.Name.Name.String()},
-			},
+			Items: items,
 		},
 	}
 }
@@ -225,10 +236,6 @@ func (c *cc) convertDeleteStmt(n *pcast.DeleteStmt) *ast.DeleteStmt {
 }
 
 func (c *cc) convertDropTableStmt(n *pcast.DropTableStmt) ast.Node {
-	// TODO: Remove once views are supported.
-	if n.IsView {
-		return &ast.TODO{}
-	}
 	drop := &ast.DropTableStmt{IfExists: n.IfExists}
 	for _, name := range n.Tables {
 		drop.Tables = append(drop.Tables, parseTableName(name))
@@ -259,9 +266,21 @@ func (c *cc) convertFieldList(n *pcast.FieldList) *ast.List {
 	return &ast.List{Items: fields}
 }
 
-func (c *cc) convertFuncCallExpr(n *pcast.FuncCallExpr) *ast.FuncCall {
+func (c *cc) convertFuncCallExpr(n *pcast.FuncCallExpr) ast.Node {
 	schema := n.Schema.String()
-	name := n.FnName.String()
+	// Function names are not case-sensitive
+	name := n.FnName.L
+
+	if name == "coalesce" {
+		coalesce := &ast.CoalesceExpr{
+			Args:     &ast.List{},
+			Location: n.Offset,
+		}
+		for _, arg := range n.Args {
+			coalesce.Args.Items = append(coalesce.Args.Items, c.convert(arg))
+		}
+		return coalesce
+	}
 
 	// TODO: Deprecate the usage of Funcname
 	items := []ast.Node{}
@@ -360,9 +379,12 @@ func (c *cc) convertSelectField(n *pcast.SelectField) *ast.ResTarget {
 func (c *cc) convertSelectStmt(n *pcast.SelectStmt) *ast.SelectStmt {
 	stmt := &ast.SelectStmt{
 		TargetList:  c.convertFieldList(n.Fields),
-		FromClause:  c.convertTableRefsClause(n.From),
+		FromClause:  c.convertFromClause(n.From),
 		WhereClause: c.convert(n.Where),
 	}
+	if n.OrderBy != nil {
+		stmt.SortClause = c.convertOrderByClause(n.OrderBy)
+	}
 	if n.Limit != nil {
 		stmt.LimitCount = c.convert(n.Limit.Count)
 		stmt.LimitOffset = c.convert(n.Limit.Offset)
@@ -394,6 +416,16 @@ func (c *cc) convertTableRefsClause(n *pcast.TableRefsClause) *ast.List {
 	return &ast.List{Items: tables}
 }
 
+// The FROM clause of a SELECT statement. Unlike convertTableRefsClause, joins
+// are kept, so that the tables on the nullable side of an outer join can be
+// found.
+func (c *cc) convertFromClause(n *pcast.TableRefsClause) *ast.List {
+	if n == nil || n.TableRefs == nil {
+		return &ast.List{}
+	}
+	return &ast.List{Items: []ast.Node{c.convertJoin(n.TableRefs)}}
+}
+
 func (c *cc) convertUpdateStmt(n *pcast.UpdateStmt) *ast.UpdateStmt {
 	// Relation
 	rels := c.convertTableRefsClause(n.TableRefs)
@@ -420,6 +452,24 @@ func (c *cc) convertUpdateStmt(n *pcast.UpdateStmt) *ast.UpdateStmt {
 }
 
 func (c *cc) convertValueExpr(n *driver.ValueExpr) *ast.A_Const {
+	switch n.Datum.Kind() {
+	case driver.KindNull:
+		return &ast.A_Const{
+			Val: &ast.Null{},
+		}
+	case driver.KindInt64, driver.KindUint64:
+		return &ast.A_Const{
+			Val: &ast.Integer{
+				Ival: n.Datum.GetInt64(),
+			},
+		}
+	case driver.KindFloat32, driver.KindFloat64, driver.KindMysqlDecimal:
+		return &ast.A_Const{
+			Val: &ast.Float{
+				Str: fmt.Sprint(n.Datum.GetValue()),
+			},
+		}
+	}
 	return &ast.A_Const{
 		Val: &ast.String{
 			Str: n.Datum.GetString(),
@@ -446,14 +496,15 @@ func (c *cc) convertAdminStmt(n *pcast.AdminStmt) ast.Node {
 }
 
 func (c *cc) convertAggregateFuncExpr(n *pcast.AggregateFuncExpr) *ast.FuncCall {
+	name := strings.ToLower(n.F)
 	fn := &ast.FuncCall{
 		Func: &ast.FuncName{
-			Name: n.F,
+			Name: name,
 		},
 		Funcname: &ast.List{
 			Items: []ast.Node{
 				&ast.String{
-					Str: n.F,
+					Str: name,
 				},
 			},
 		},
@@ -512,7 +563,14 @@ func (c *cc) convertBinlogStmt(n *pcast.BinlogStmt) ast.Node {
 }
 
 func (c *cc) convertByItem(n *pcast.ByItem) ast.Node {
-	return &ast.TODO{}
+	sortBy := &ast.SortBy{
+		Node:      c.convert(n.Expr),
+		SortbyDir: ast.SORTBY_DEFAULT,
+	}
+	if n.Desc {
+		sortBy.SortbyDir = ast.SORTBY_DESC
+	}
+	return sortBy
 }
 
 func (c *cc) convertCaseExpr(n *pcast.CaseExpr) ast.Node {
@@ -576,7 +634,26 @@ func (c *cc) convertCreateUserStmt(n *pcast.CreateUserStmt) ast.Node {
 }
 
 func (c *cc) convertCreateViewStmt(n *pcast.CreateViewStmt) ast.Node {
-	return &ast.TODO{}
+	name := parseTableName(n.ViewName)
+	view := &ast.RangeVar{
+		Relname: &name.Name,
+	}
+	if name.Schema != "" {
+		view.Schemaname = &name.Schema
+	}
+	var aliases *ast.List
+	if len(n.Cols) > 0 {
+		aliases = &ast.List{}
+		for _, col := range n.Cols {
+			aliases.Items = append(aliases.Items, &ast.String{Str: col.String()})
+		}
+	}
+	return &ast.ViewStmt{
+		View:    view,
+		Aliases: aliases,
+		Query:   c.convert(n.Select),
+		Replace: n.OrReplace,
+	}
 }
 
 func (c *cc) convertDeallocateStmt(n *pcast.DeallocateStmt) ast.Node {
@@ -696,7 +773,31 @@ func (c *cc) convertIsTruthExpr(n *pcast.IsTruthExpr) ast.Node {
 }
 
 func (c *cc) convertJoin(n *pcast.Join) ast.Node {
-	return &ast.TODO{}
+	if n.Right == nil {
+		return c.convert(n.Left)
+	}
+	join := &ast.JoinExpr{
+		Jointype:  ast.JOIN_INNER,
+		IsNatural: n.NaturalJoin,
+		Larg:      c.convert(n.Left),
+		Rarg:      c.convert(n.Right),
+	}
+	switch n.Tp {
+	case pcast.LeftJoin:
+		join.Jointype = ast.JOIN_LEFT
+	case pcast.RightJoin:
+		join.Jointype = ast.JOIN_RIGHT
+	}
+	if n.On != nil {
+		join.Quals = c.convertOnCondition(n.On)
+	}
+	if len(n.Using) > 0 {
+		join.UsingClause = &ast.List{}
+		for _, col := range n.Using {
+			join.UsingClause.Items = append(join.UsingClause.Items, &ast.String{Str: col.Name.O})
+		}
+	}
+	return join
 }
 
 func (c *cc) convertKillStmt(n *pcast.KillStmt) ast.Node {
@@ -728,7 +829,7 @@ func (c *cc) convertMaxValueExpr(n *pcast.MaxValueExpr) ast.Node {
 }
 
 func (c *cc) convertOnCondition(n *pcast.OnCondition) ast.Node {
-	return &ast.TODO{}
+	return c.convert(n.Expr)
 }
 
 func (c *cc) convertOnDeleteOpt(n *pcast.OnDeleteOpt) ast.Node {
@@ -739,8 +840,15 @@ func (c *cc) convertOnUpdateOpt(n *pcast.OnUpdateOpt) ast.Node {
 	return &ast.TODO{}
 }
 
-func (c *cc) convertOrderByClause(n *pcast.OrderByClause) ast.Node {
-	return &ast.TODO{}
+func (c *cc) convertOrderByClause(n *pcast.OrderByClause) *ast.List {
+	list := &ast.List{}
+	if n == nil {
+		return list
+	}
+	for _, item := range n.Items {
+		list.Items = append(list.Items, c.convertByItem(item))
+	}
+	return list
 }
 
 func (c *cc) convertParenthesesExpr(n *pcast.ParenthesesExpr) ast.Node {
@@ -819,12 +927,67 @@ func (c *cc) convertSetDefaultRoleStmt(n *pcast.SetDefaultRoleStmt) ast.Node {
 	return &ast.TODO{}
 }
 
+// Combine the SELECT statements from left to right, so that
+// `a UNION b UNION c` is `(a UNION b) UNION c`
+// INTERSECT binds more tightly than UNION and EXCEPT, so the arms joined by
+// INTERSECT are combined first, then the resulting groups from left to right
 func (c *cc) convertSetOprSelectList(n *pcast.SetOprSelectList) ast.Node {
-	return &ast.TODO{}
+	var groups []*ast.SelectStmt
+	var ops []*pcast.SetOprType
+	for _, sel := range n.Selects {
+		arm := c.convertSelectStmt(sel)
+		if len(groups) > 0 && sel.AfterSetOperator != nil && *sel.AfterSetOperator == pcast.Intersect {
+			last := len(groups) - 1
+			groups[last] = setOperation(ast.SETOP_INTERSECT, false, groups[last], arm)
+			continue
+		}
+		groups = append(groups, arm)
+		ops = append(ops, sel.AfterSetOperator)
+	}
+	if len(groups) == 0 {
+		return &ast.TODO{}
+	}
+	stmt := groups[0]
+	for i := 1; i < len(groups); i++ {
+		op := ast.SETOP_UNION
+		var all bool
+		if ops[i] != nil {
+			switch *ops[i] {
+			case pcast.UnionAll:
+				all = true
+			case pcast.Except:
+				op = ast.SETOP_EXCEPT
+			}
+		}
+		stmt = setOperation(op, all, stmt, groups[i])
+	}
+	return stmt
+}
+
+func setOperation(op ast.SetOperation, all bool, larg, rarg *ast.SelectStmt) *ast.SelectStmt {
+	return &ast.SelectStmt{
+		TargetList: &ast.List{},
+		FromClause: &ast.List{},
+		Op:         op,
+		All:        all,
+		Larg:       larg,
+		Rarg:       rarg,
+	}
 }
 
 func (c *cc) convertSetOprStmt(n *pcast.SetOprStmt) ast.Node {
-	return &ast.TODO{}
+	stmt, ok := c.convertSetOprSelectList(n.SelectList).(*ast.SelectStmt)
+	if !ok {
+		return &ast.TODO{}
+	}
+	if n.OrderBy != nil {
+		stmt.SortClause = c.convertOrderByClause(n.OrderBy)
+	}
+	if n.Limit != nil {
+		stmt.LimitCount = c.convert(n.Limit.Count)
+		stmt.LimitOffset = c.convert(n.Limit.Offset)
+	}
+	return stmt
 }
 
 func (c *cc) convertSetPwdStmt(n *pcast.SetPwdStmt) ast.Node {
@@ -852,7 +1015,12 @@ func (c *cc) convertSplitRegionStmt(n *pcast.SplitRegionStmt) ast.Node {
 }
 
 func (c *cc) convertTableName(n *pcast.TableName) ast.Node {
-	return &ast.TODO{}
+	schema := n.Schema.String()
+	rel := n.Name.String()
+	return &ast.RangeVar{
+		Schemaname: &schema,
+		Relname:    &rel,
+	}
 }
 
 func (c *cc) convertTableNameExpr(n *pcast.TableNameExpr) ast.Node {
@@ -864,7 +1032,24 @@ func (c *cc) convertTableOptimizerHint(n *pcast.TableOptimizerHint) ast.Node {
 }
 
 func (c *cc) convertTableSource(n *pcast.TableSource) ast.Node {
-	return &ast.TODO{}
+	var alias *ast.Alias
+	if n.AsName.O != "" {
+		name := n.AsName.String()
+		alias = &ast.Alias{Aliasname: &name}
+	}
+	switch src := n.Source.(type) {
+	case *pcast.TableName:
+		rv := c.convertTableName(src).(*ast.RangeVar)
+		rv.Alias = alias
+		return rv
+	case *pcast.Join:
+		return c.convertJoin(src)
+	default:
+		return &ast.RangeSubselect{
+			Subquery: c.convert(src),
+			Alias:    alias,
+		}
+	}
 }
 
 func (c *cc) convertTableToTable(n *pcast.TableToTable) ast.Node {
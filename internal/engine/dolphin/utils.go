@@ -71,6 +71,18 @@ func parseTableName(n *pcast.TableName) *ast.TableName {
 	}
 }
 
+func isUnique(n *pcast.ColumnDef) bool {
+	for i := range n.Options {
+		if n.Options[i].Tp == pcast.ColumnOptionPrimaryKey {
+			return true
+		}
+		if n.Options[i].Tp == pcast.ColumnOptionUniqKey {
+			return true
+		}
+	}
+	return false
+}
+
 func isNotNull(n *pcast.ColumnDef) bool {
 	for i := range n.Options {
 		if n.Options[i].Tp == pcast.ColumnOptionNotNull {
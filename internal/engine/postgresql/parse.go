@@ -249,7 +249,7 @@ func translate(node nodes.Node) (ast.Node, error) {
 	case nodes.AlterObjectSchemaStmt:
 		switch n.ObjectType {
 
-		case nodes.OBJECT_TABLE:
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW:
 			tbl, err := parseTableName(*n.Relation)
 			if err != nil {
 				return nil, err
@@ -395,6 +395,7 @@ func translate(node nodes.Node) (ast.Node, error) {
 			Name:        name,
 			IfNotExists: n.IfNotExists,
 		}
+		var keys []string
 		for _, elt := range n.TableElts.Items {
 			switch n := elt.(type) {
 			case nodes.ColumnDef:
@@ -407,7 +408,23 @@ func translate(node nodes.Node) (ast.Node, error) {
 					TypeName:  tn,
 					IsNotNull: isNotNull(n),
 					IsArray:   isArray(n.TypeName),
+					IsUnique:  isUnique(n),
 				})
+			case nodes.Constraint:
+				// Only single column keys guarantee that the column is unique
+				if n.Contype != nodes.CONSTR_PRIMARY && n.Contype != nodes.CONSTR_UNIQUE {
+					continue
+				}
+				if cols := stringSlice(n.Keys); len(cols) == 1 {
+					keys = append(keys, cols[0])
+				}
+			}
+		}
+		for _, key := range keys {
+			for _, col := range create.Cols {
+				if col.Colname == key {
+					col.IsUnique = true
+				}
 			}
 		}
 		return create, nil
@@ -523,7 +540,7 @@ func translate(node nodes.Node) (ast.Node, error) {
 			}
 			return drop, nil
 
-		case nodes.OBJECT_TABLE:
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW:
 			drop := &ast.DropTableStmt{
 				IfExists: n.MissingOk,
 			}
@@ -566,7 +583,7 @@ func translate(node nodes.Node) (ast.Node, error) {
 				NewName: n.Newname,
 			}, nil
 
-		case nodes.OBJECT_TABLE:
+		case nodes.OBJECT_TABLE, nodes.OBJECT_VIEW, nodes.OBJECT_MATVIEW:
 			tbl, err := parseTableName(*n.Relation)
 			if err != nil {
 				return nil, fmt.Errorf("nodes.RenameType: TABLE: %w", err)
@@ -29,6 +29,21 @@ func isNotNull(n nodes.ColumnDef) bool {
 	return false
 }
 
+func isUnique(n nodes.ColumnDef) bool {
+	for _, c := range n.Constraints.Items {
+		switch n := c.(type) {
+		case nodes.Constraint:
+			if n.Contype == nodes.CONSTR_PRIMARY {
+				return true
+			}
+			if n.Contype == nodes.CONSTR_UNIQUE {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func IsNamedParamFunc(node nodes.Node) bool {
 	fun, ok := node.(nodes.FuncCall)
 	return ok && join(fun.Funcname, ".") == "sqlc.arg"
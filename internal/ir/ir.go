@@ -0,0 +1,282 @@
+// Package ir defines a stable, versioned JSON serialization of a compiled
+// package: the catalog built from the schema and the queries checked against
+// it. Tools that want sqlc's view of a database can read these files instead
+// of depending on sqlc's internal packages.
+package ir
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/catalog"
+)
+
+// Version is incremented whenever a field is removed or its meaning changes.
+// Adding fields does not change the version.
+const Version = 1
+
+const (
+	defaultFilename = "ir.json"
+	defaultIndent   = "  "
+)
+
+type File struct {
+	Version int     `json:"version"`
+	Engine  string  `json:"engine"`
+	Package string  `json:"package"`
+	Catalog Catalog `json:"catalog"`
+	Queries []Query `json:"queries"`
+}
+
+type Catalog struct {
+	Name          string   `json:"name"`
+	DefaultSchema string   `json:"default_schema"`
+	Comment       string   `json:"comment"`
+	Schemas       []Schema `json:"schemas"`
+}
+
+type Schema struct {
+	Name           string          `json:"name"`
+	Comment        string          `json:"comment"`
+	Tables         []Table         `json:"tables"`
+	Enums          []Enum          `json:"enums"`
+	CompositeTypes []CompositeType `json:"composite_types"`
+	Functions      []Function      `json:"functions"`
+}
+
+// An Identifier is a possibly qualified name of a table or type
+type Identifier struct {
+	Catalog string `json:"catalog,omitempty"`
+	Schema  string `json:"schema,omitempty"`
+	Name    string `json:"name"`
+}
+
+type Table struct {
+	Rel     Identifier `json:"rel"`
+	Comment string     `json:"comment"`
+	Columns []Column   `json:"columns"`
+}
+
+type Column struct {
+	Name    string `json:"name"`
+	NotNull bool   `json:"not_null"`
+	IsArray bool   `json:"is_array"`
+	Comment string `json:"comment"`
+	// The type name used by the code generators, e.g. text or myschema.mood
+	DataType string      `json:"data_type"`
+	Type     *Identifier `json:"type,omitempty"`
+	// The table a query column or parameter refers to, if any
+	Table *Identifier `json:"table,omitempty"`
+}
+
+type Enum struct {
+	Name    string   `json:"name"`
+	Vals    []string `json:"vals"`
+	Comment string   `json:"comment"`
+}
+
+type CompositeType struct {
+	Name    string `json:"name"`
+	Comment string `json:"comment"`
+}
+
+type Function struct {
+	Name       string      `json:"name"`
+	Args       []Argument  `json:"args"`
+	ReturnType *Identifier `json:"return_type"`
+	Comment    string      `json:"comment"`
+}
+
+type Argument struct {
+	Name       string      `json:"name"`
+	Type       *Identifier `json:"type"`
+	HasDefault bool        `json:"has_default"`
+	// One of in, out, inout, variadic or table
+	Mode string `json:"mode"`
+}
+
+type Query struct {
+	Name     string      `json:"name"`
+	Cmd      string      `json:"cmd"`
+	Filename string      `json:"filename"`
+	Comments []string    `json:"comments"`
+	SQL      string      `json:"sql"`
+	Params   []Parameter `json:"params"`
+	Columns  []Column    `json:"columns"`
+}
+
+type Parameter struct {
+	Number int    `json:"number"`
+	Column Column `json:"column"`
+}
+
+// Build converts a compiled package. Built-in schemas, such as pg_catalog,
+// are left out.
+func Build(engine config.Engine, pkg string, result *compiler.Result) *File {
+	f := &File{
+		Version: Version,
+		Engine:  string(engine),
+		Package: pkg,
+		Catalog: buildCatalog(result.Catalog),
+		Queries: []Query{},
+	}
+	for _, q := range result.Queries {
+		if q.Name == "" {
+			continue
+		}
+		f.Queries = append(f.Queries, buildQuery(q))
+	}
+	return f
+}
+
+// Generate returns the serialized package, keyed by the configured filename
+func Generate(result *compiler.Result, settings config.CombinedSettings) (map[string]string, error) {
+	opts := settings.JSON
+	filename := opts.Filename
+	if filename == "" {
+		filename = defaultFilename
+	}
+	indent := opts.Indent
+	if indent == "" {
+		indent = defaultIndent
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", indent)
+	if err := enc.Encode(Build(settings.Package.Engine, opts.Package, result)); err != nil {
+		return nil, err
+	}
+	return map[string]string{filename: buf.String()}, nil
+}
+
+func buildCatalog(c *catalog.Catalog) Catalog {
+	out := Catalog{
+		Name:          c.Name,
+		DefaultSchema: c.DefaultSchema,
+		Comment:       c.Comment,
+		Schemas:       []Schema{},
+	}
+	for _, s := range c.Schemas {
+		if s.Name == "pg_catalog" {
+			continue
+		}
+		out.Schemas = append(out.Schemas, buildSchema(s))
+	}
+	return out
+}
+
+func buildSchema(s *catalog.Schema) Schema {
+	out := Schema{
+		Name:           s.Name,
+		Comment:        s.Comment,
+		Tables:         []Table{},
+		Enums:          []Enum{},
+		CompositeTypes: []CompositeType{},
+		Functions:      []Function{},
+	}
+	for _, t := range s.Tables {
+		table := Table{
+			Rel:     identifier(t.Rel.Catalog, t.Rel.Schema, t.Rel.Name),
+			Comment: t.Comment,
+			Columns: []Column{},
+		}
+		for _, c := range t.Columns {
+			col := buildColumn(compiler.ConvertColumn(nil, c))
+			col.Comment = c.Comment
+			table.Columns = append(table.Columns, col)
+		}
+		out.Tables = append(out.Tables, table)
+	}
+	for _, typ := range s.Types {
+		switch t := typ.(type) {
+		case *catalog.Enum:
+			vals := append([]string{}, t.Vals...)
+			out.Enums = append(out.Enums, Enum{Name: t.Name, Vals: vals, Comment: t.Comment})
+		case *catalog.CompositeType:
+			out.CompositeTypes = append(out.CompositeTypes, CompositeType{Name: t.Name, Comment: t.Comment})
+		}
+	}
+	for _, fn := range s.Funcs {
+		f := Function{
+			Name:       fn.Name,
+			Args:       []Argument{},
+			ReturnType: typeIdentifier(fn.ReturnType),
+			Comment:    fn.Comment,
+		}
+		for _, arg := range fn.Args {
+			f.Args = append(f.Args, Argument{
+				Name:       arg.Name,
+				Type:       typeIdentifier(arg.Type),
+				HasDefault: arg.HasDefault,
+				Mode:       argMode(arg.Mode),
+			})
+		}
+		out.Functions = append(out.Functions, f)
+	}
+	return out
+}
+
+func buildQuery(q *compiler.Query) Query {
+	out := Query{
+		Name:     q.Name,
+		Cmd:      q.Cmd,
+		Filename: q.Filename,
+		Comments: append([]string{}, q.Comments...),
+		SQL:      q.SQL,
+		Params:   []Parameter{},
+		Columns:  []Column{},
+	}
+	for _, p := range q.Params {
+		out.Params = append(out.Params, Parameter{Number: p.Number, Column: buildColumn(p.Column)})
+	}
+	for _, c := range q.Columns {
+		out.Columns = append(out.Columns, buildColumn(c))
+	}
+	return out
+}
+
+func buildColumn(c *compiler.Column) Column {
+	col := Column{
+		Name:     c.Name,
+		NotNull:  c.NotNull,
+		IsArray:  c.IsArray,
+		Comment:  c.Comment,
+		DataType: c.DataType,
+		Type:     typeIdentifier(c.Type),
+	}
+	if c.Table != nil {
+		rel := identifier(c.Table.Catalog, c.Table.Schema, c.Table.Name)
+		col.Table = &rel
+	}
+	return col
+}
+
+func identifier(catalog, schema, name string) Identifier {
+	return Identifier{Catalog: catalog, Schema: schema, Name: name}
+}
+
+func typeIdentifier(t *ast.TypeName) *Identifier {
+	if t == nil {
+		return nil
+	}
+	id := identifier(t.Catalog, t.Schema, t.Name)
+	return &id
+}
+
+func argMode(m ast.FuncParamMode) string {
+	switch m {
+	case ast.FuncParamOut:
+		return "out"
+	case ast.FuncParamInOut:
+		return "inout"
+	case ast.FuncParamVariadic:
+		return "variadic"
+	case ast.FuncParamTable:
+		return "table"
+	default:
+		return "in"
+	}
+}
@@ -20,8 +20,17 @@ const (
 	CmdExecRows   = ":execrows"
 	CmdMany       = ":many"
 	CmdOne        = ":one"
+	CmdBatchExec  = ":batchexec"
+	CmdBatchMany  = ":batchmany"
+	CmdBatchOne   = ":batchone"
+	CmdCopyFrom   = ":copyfrom"
 )
 
+// Whether cmd queues one query per parameter set into a single batch
+func IsBatch(cmd string) bool {
+	return cmd == CmdBatchExec || cmd == CmdBatchMany || cmd == CmdBatchOne
+}
+
 // A query name must be a valid Go identifier
 //
 // https://golang.org/ref/spec#Identifiers
@@ -55,7 +64,7 @@ func Parse(t string, commentStyle CommentSyntax) (string, string, error) {
 			part = part[:len(part)-1] // removes the trailing "*/" element
 		}
 		if len(part) == 2 {
-			return "", "", fmt.Errorf("missing query type [':one', ':many', ':exec', ':execrows', ':execresult']: %s", line)
+			return "", "", fmt.Errorf("missing query type [':one', ':many', ':exec', ':execrows', ':execresult', ':batchexec', ':batchone', ':batchmany', ':copyfrom']: %s", line)
 		}
 		if len(part) != 4 {
 			return "", "", fmt.Errorf("invalid query comment: %s", line)
@@ -63,7 +72,7 @@ func Parse(t string, commentStyle CommentSyntax) (string, string, error) {
 		queryName := part[2]
 		queryType := strings.TrimSpace(part[3])
 		switch queryType {
-		case CmdOne, CmdMany, CmdExec, CmdExecResult, CmdExecRows:
+		case CmdOne, CmdMany, CmdExec, CmdExecResult, CmdExecRows, CmdBatchExec, CmdBatchMany, CmdBatchOne, CmdCopyFrom:
 		default:
 			return "", "", fmt.Errorf("invalid query type: %s", queryType)
 		}
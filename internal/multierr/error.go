@@ -11,7 +11,10 @@ type FileError struct {
 	Filename string
 	Line     int
 	Column   int
-	Err      error
+	// The position just past the token the error refers to
+	EndLine   int
+	EndColumn int
+	Err       error
 }
 
 func (e *FileError) Unwrap() error {
@@ -33,10 +36,19 @@ func (e *Error) Add(filename, in string, loc int, err error) {
 			column = lerr.Column
 		}
 	}
+	endLine, endColumn := line, column
 	if in != "" && loc != 0 {
 		line, column = source.LineNumber(in, loc)
+		endLine, endColumn = source.TokenEnd(in, loc)
 	}
-	e.errs = append(e.errs, &FileError{filename, line, column, err})
+	e.errs = append(e.errs, &FileError{
+		Filename:  filename,
+		Line:      line,
+		Column:    column,
+		EndLine:   endLine,
+		EndColumn: endColumn,
+		Err:       err,
+	})
 }
 
 func (e *Error) Errs() []*FileError {
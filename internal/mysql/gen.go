@@ -61,7 +61,7 @@ func stripInnerQuotes(identifier string) string {
 
 func (pGen PackageGenerator) enumNameFromColDef(col *sqlparser.ColumnDefinition) string {
 	return fmt.Sprintf("%sType",
-		golang.StructName(col.Name.String(), pGen.CombinedSettings))
+		golang.EnumName(col.Name.String(), pGen.CombinedSettings))
 }
 
 // Structs marshels each query into a go struct for generation
@@ -86,7 +86,7 @@ func (r *Result) Structs(settings config.CombinedSettings) []golang.Struct {
 				tags["json:"] = col.Name.String()
 			}
 			s.Fields = append(s.Fields, golang.Field{
-				Name:    golang.StructName(col.Name.String(), settings),
+				Name:    golang.FieldName(col.Name.String(), settings),
 				Type:    r.goTypeCol(Column{col, tableName}),
 				Tags:    tags,
 				Comment: "",
@@ -114,11 +114,12 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []golang.Query {
 			continue
 		}
 
+		methodName := golang.MethodName(query.Name, settings)
 		gq := golang.Query{
 			Cmd:          query.Cmd,
-			ConstantName: codegen.LowerTitle(query.Name),
-			FieldName:    codegen.LowerTitle(query.Name) + "Stmt",
-			MethodName:   query.Name,
+			ConstantName: codegen.LowerTitle(methodName),
+			FieldName:    codegen.LowerTitle(methodName) + "Stmt",
+			MethodName:   methodName,
 			SourceName:   query.Filename,
 			SQL:          query.SQL,
 			// Comments:     query.Comments,
@@ -178,7 +179,7 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []golang.Query {
 				same := true
 				for i, f := range s.Fields {
 					c := query.Columns[i]
-					sameName := f.Name == golang.StructName(columnName(c.ColumnDefinition, i), settings)
+					sameName := f.Name == golang.FieldName(columnName(c.ColumnDefinition, i), settings)
 					sameType := f.Type == r.goTypeCol(c)
 
 					hackedFQN := core.FQN{c.Table, "", ""} // TODO: only check needed here is equality to see if struct can be reused, this type should be removed or properly used
@@ -232,7 +233,7 @@ func (r *Result) columnsToStruct(name string, items []structParams, settings con
 		name := item.originalName
 		typ := item.goType
 		tagName := name
-		fieldName := golang.StructName(name, settings)
+		fieldName := golang.FieldName(name, settings)
 		if v := seen[name]; v > 0 {
 			tagName = fmt.Sprintf("%s_%d", tagName, v+1)
 			fieldName = fmt.Sprintf("%s_%d", fieldName, v+1)
@@ -262,7 +263,7 @@ func (pGen PackageGenerator) goTypeCol(col Column) string {
 	for _, oride := range pGen.Overrides {
 		shouldOverride := (oride.DBType != "" && oride.DBType == mySQLType && oride.Nullable != notNull) ||
 			(oride.ColumnName != "" && oride.ColumnName == colName && oride.Table.Rel == col.Table)
-		if shouldOverride {
+		if shouldOverride && oride.GoTypeName != "" {
 			return oride.GoTypeName
 		}
 	}
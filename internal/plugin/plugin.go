@@ -0,0 +1,160 @@
+// Package plugin runs external code generators. A plugin is any executable.
+// For each package, sqlc starts the plugin in the directory holding the
+// configuration file, writes a JSON encoded Request to its stdin and reads a
+// JSON encoded Response from its stdout. A plugin reports an error by exiting
+// with a non-zero status; anything it wrote to stderr is shown to the user.
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/compiler"
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/ir"
+)
+
+type Request struct {
+	// The version of the package format, see ir.Version
+	Version  int      `json:"version"`
+	Settings Settings `json:"settings"`
+	Package  *ir.File `json:"package"`
+}
+
+type Settings struct {
+	Engine string `json:"engine"`
+	// Paths relative to the configuration file
+	Schema  []string `json:"schema"`
+	Queries []string `json:"queries"`
+	Package string   `json:"package"`
+	Out     string   `json:"out"`
+	// The options of the gen entry, passed as is
+	Options     map[string]interface{} `json:"options"`
+	Rename      map[string]string      `json:"rename"`
+	RenameRules []RenameRule           `json:"rename_rules"`
+	Overrides   []Override             `json:"overrides"`
+}
+
+type RenameRule struct {
+	Match   string   `json:"match"`
+	Replace string   `json:"replace"`
+	Kinds   []string `json:"kinds"`
+}
+
+type Override struct {
+	GoType   string `json:"go_type"`
+	DBType   string `json:"db_type"`
+	Column   string `json:"column"`
+	Nullable bool   `json:"nullable"`
+}
+
+type Response struct {
+	Files []File `json:"files"`
+}
+
+// A File is written to Name, relative to the output directory
+type File struct {
+	Name     string `json:"name"`
+	Contents string `json:"contents"`
+}
+
+// Generate runs the plugin named in gen for a compiled package and returns
+// the files it generated, keyed by their path relative to the output
+// directory.
+func Generate(dir string, result *compiler.Result, settings config.CombinedSettings, gen config.SQLPlugin) (map[string]string, error) {
+	var p *config.Plugin
+	for i := range settings.Global.Plugins {
+		if settings.Global.Plugins[i].Name == gen.Plugin {
+			p = &settings.Global.Plugins[i]
+		}
+	}
+	if p == nil {
+		return nil, fmt.Errorf("unknown plugin %q", gen.Plugin)
+	}
+
+	req := Request{
+		Version: ir.Version,
+		Settings: Settings{
+			Engine:      string(settings.Package.Engine),
+			Schema:      settings.Package.Schema,
+			Queries:     settings.Package.Queries,
+			Package:     gen.Package,
+			Out:         gen.Out,
+			Options:     gen.Options,
+			Rename:      settings.Rename,
+			RenameRules: []RenameRule{},
+			Overrides:   []Override{},
+		},
+		Package: ir.Build(settings.Package.Engine, gen.Package, result),
+	}
+	for _, r := range settings.RenameRules {
+		req.Settings.RenameRules = append(req.Settings.RenameRules, RenameRule{
+			Match:   r.Match,
+			Replace: r.Replace,
+			Kinds:   r.Kinds,
+		})
+	}
+	for _, o := range settings.Overrides {
+		req.Settings.Overrides = append(req.Settings.Overrides, Override{
+			GoType:   o.GoType,
+			DBType:   o.DBType,
+			Column:   o.Column,
+			Nullable: o.Nullable,
+		})
+	}
+
+	resp, err := run(dir, *p, &req)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(resp.Files))
+	for _, f := range resp.Files {
+		name := filepath.Clean(filepath.FromSlash(f.Name))
+		if f.Name == "" || filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("plugin %s: invalid file name %q", p.Name, f.Name)
+		}
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("plugin %s: duplicate file name %q", p.Name, f.Name)
+		}
+		files[name] = f.Contents
+	}
+	return files, nil
+}
+
+func run(dir string, p config.Plugin, req *Request) (*Response, error) {
+	blob, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relative paths, unlike bare command names, are resolved against the
+	// directory holding the configuration file
+	name := p.Cmd
+	if strings.ContainsRune(name, '/') && !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(name, p.Args...)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(blob)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("plugin %s: %s: %s", p.Name, err, msg)
+		}
+		return nil, fmt.Errorf("plugin %s: %w", p.Name, err)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("plugin %s: invalid response: %w", p.Name, err)
+	}
+	return &resp, nil
+}
@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 type Edit struct {
@@ -44,6 +45,62 @@ func LineNumber(source string, head int) (int, int) {
 	return line + 1, col
 }
 
+// TokenEnd returns the line and column just past the token at or after head.
+// Whitespace and comments are skipped the same way as in LineNumber.
+func TokenEnd(source string, head int) (int, int) {
+	_, i := TokenSpan(source, head)
+	line := 1 + strings.Count(source[:i], "\n")
+	start := strings.LastIndexByte(source[:i], '\n') + 1
+	return line, utf8.RuneCountInString(source[start:i]) + 1
+}
+
+// TokenSpan returns the start and end offsets of the token at or after head.
+// Quoted identifiers and strings are a single token, as are qualified names
+// such as public.authors.id.
+func TokenSpan(source string, head int) (int, int) {
+	i := head
+	for i < len(source) {
+		if source[i] == '-' && i+1 < len(source) && source[i+1] == '-' {
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+			continue
+		}
+		if !unicode.IsSpace(rune(source[i])) {
+			break
+		}
+		i++
+	}
+	start := i
+	if i < len(source) {
+		r, size := utf8.DecodeRuneInString(source[i:])
+		switch {
+		case r == '"' || r == '\'':
+			end := strings.IndexRune(source[i+1:], r)
+			if end < 0 {
+				i = len(source)
+			} else {
+				i += end + 2
+			}
+		case isIdentChar(r):
+			for i < len(source) {
+				r, size := utf8.DecodeRuneInString(source[i:])
+				if !isIdentChar(r) && r != '.' {
+					break
+				}
+				i += size
+			}
+		default:
+			i += size
+		}
+	}
+	return start, i
+}
+
+func isIdentChar(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
 func Pluck(source string, location, length int) (string, error) {
 	head := location
 	tail := location + length
@@ -2,6 +2,25 @@ package ast
 
 type A_Expr_Kind uint
 
+const (
+	AEXPR_OP A_Expr_Kind = iota
+	AEXPR_OP_ANY
+	AEXPR_OP_ALL
+	AEXPR_DISTINCT
+	AEXPR_NOT_DISTINCT
+	AEXPR_NULLIF
+	AEXPR_OF
+	AEXPR_IN
+	AEXPR_LIKE
+	AEXPR_ILIKE
+	AEXPR_SIMILAR
+	AEXPR_BETWEEN
+	AEXPR_NOT_BETWEEN
+	AEXPR_BETWEEN_SYM
+	AEXPR_NOT_BETWEEN_SYM
+	AEXPR_PAREN
+)
+
 func (n *A_Expr_Kind) Pos() int {
 	return 0
 }
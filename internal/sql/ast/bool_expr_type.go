@@ -2,6 +2,12 @@ package ast
 
 type BoolExprType uint
 
+const (
+	AND_EXPR BoolExprType = iota
+	OR_EXPR
+	NOT_EXPR
+)
+
 func (n *BoolExprType) Pos() int {
 	return 0
 }
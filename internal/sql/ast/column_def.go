@@ -5,6 +5,7 @@ type ColumnDef struct {
 	TypeName  *TypeName
 	IsNotNull bool
 	IsArray   bool
+	IsUnique  bool
 	Vals      *List
 
 	// From pg.ColumnDef
@@ -2,6 +2,17 @@ package ast
 
 type JoinType uint
 
+const (
+	JOIN_INNER JoinType = iota
+	JOIN_LEFT
+	JOIN_FULL
+	JOIN_RIGHT
+	JOIN_SEMI
+	JOIN_ANTI
+	JOIN_UNIQUE_OUTER
+	JOIN_UNIQUE_INNER
+)
+
 func (n *JoinType) Pos() int {
 	return 0
 }
@@ -2,6 +2,13 @@ package ast
 
 type SetOperation uint
 
+const (
+	SETOP_NONE SetOperation = iota
+	SETOP_UNION
+	SETOP_INTERSECT
+	SETOP_EXCEPT
+)
+
 func (n *SetOperation) Pos() int {
 	return 0
 }
@@ -2,6 +2,13 @@ package ast
 
 type SortByDir uint
 
+const (
+	SORTBY_DEFAULT SortByDir = iota
+	SORTBY_ASC
+	SORTBY_DESC
+	SORTBY_USING
+)
+
 func (n *SortByDir) Pos() int {
 	return 0
 }
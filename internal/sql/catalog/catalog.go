@@ -180,6 +180,8 @@ type Table struct {
 	Rel     *ast.TableName
 	Columns []*Column
 	Comment string
+	// Set for views, which CREATE OR REPLACE VIEW may redefine
+	IsView bool
 }
 
 // TODO: Should this just be ast Nodes?
@@ -188,6 +190,7 @@ type Column struct {
 	Type      ast.TypeName
 	IsNotNull bool
 	IsArray   bool
+	IsUnique  bool
 	Comment   string
 }
 
@@ -260,16 +263,19 @@ func New(def string) *Catalog {
 	}
 }
 
+// Build the catalog from a schema which doesn't define any views
 func (c *Catalog) Build(stmts []ast.Statement) error {
 	for i := range stmts {
-		if err := c.Update(stmts[i]); err != nil {
+		if err := c.Update(stmts[i], nil); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *Catalog) Update(stmt ast.Statement) error {
+// Update the catalog with a schema statement. colGen computes the columns of
+// the views the statement defines.
+func (c *Catalog) Update(stmt ast.Statement, colGen columnGenerator) error {
 	if stmt.Raw == nil {
 		return nil
 	}
@@ -314,6 +320,9 @@ func (c *Catalog) Update(stmt ast.Statement) error {
 	case *ast.CreateSchemaStmt:
 		err = c.createSchema(n)
 
+	case *ast.CreateTableAsStmt:
+		err = c.createTableAs(n, colGen)
+
 	case *ast.CreateTableStmt:
 		err = c.createTable(n)
 
@@ -335,6 +344,9 @@ func (c *Catalog) Update(stmt ast.Statement) error {
 	case *ast.RenameTableStmt:
 		err = c.renameTable(n)
 
+	case *ast.ViewStmt:
+		err = c.createView(n, colGen)
+
 	}
 	return err
 }
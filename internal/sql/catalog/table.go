@@ -158,6 +158,7 @@ func (c *Catalog) createTable(stmt *ast.CreateTableStmt) error {
 				Type:      *col.TypeName,
 				IsNotNull: col.IsNotNull,
 				IsArray:   col.IsArray,
+				IsUnique:  col.IsUnique,
 				Comment:   col.Comment,
 			}
 			if col.Vals != nil {
@@ -0,0 +1,92 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
+)
+
+// The catalog can't compute the columns of a view on its own, as the types of
+// the view's SELECT depend on the rest of the catalog
+type columnGenerator interface {
+	OutputColumns(node ast.Node) ([]*Column, error)
+}
+
+func rangeVarName(rv *ast.RangeVar) *ast.TableName {
+	name := &ast.TableName{}
+	if rv.Catalogname != nil {
+		name.Catalog = *rv.Catalogname
+	}
+	if rv.Schemaname != nil {
+		name.Schema = *rv.Schemaname
+	}
+	if rv.Relname != nil {
+		name.Name = *rv.Relname
+	}
+	return name
+}
+
+func (c *Catalog) createView(stmt *ast.ViewStmt, colGen columnGenerator) error {
+	if stmt.View == nil {
+		return errors.New("create view statement has no name")
+	}
+	return c.createRelation(rangeVarName(stmt.View), stmt.Query, stmt.Aliases, true, stmt.Replace, false, colGen)
+}
+
+// Materialized views and CREATE TABLE AS both define a relation from a query
+func (c *Catalog) createTableAs(stmt *ast.CreateTableAsStmt, colGen columnGenerator) error {
+	if stmt.Into == nil || stmt.Into.Rel == nil {
+		return errors.New("create table as statement has no name")
+	}
+	return c.createRelation(rangeVarName(stmt.Into.Rel), stmt.Query, stmt.Into.ColNames, false, false, stmt.IfNotExists, colGen)
+}
+
+func (c *Catalog) createRelation(name *ast.TableName, query ast.Node, aliases *ast.List, view, replace, ifNotExists bool, colGen columnGenerator) error {
+	if colGen == nil {
+		return fmt.Errorf("relation \"%s\" is defined by a query, which can't be typed here", name.Name)
+	}
+	ns := name.Schema
+	if ns == "" {
+		ns = c.DefaultSchema
+	}
+	schema, err := c.getSchema(ns)
+	if err != nil {
+		return err
+	}
+	existing, idx, err := schema.getTable(name)
+	if err == nil && ifNotExists {
+		return nil
+	} else if err == nil && !replace {
+		return sqlerr.RelationExists(name.Name)
+	} else if err == nil && !existing.IsView {
+		return &sqlerr.Error{
+			Code:    "42809",
+			Message: fmt.Sprintf("\"%s\" is not a view", name.Name),
+		}
+	}
+
+	cols, err := colGen.OutputColumns(query)
+	if err != nil {
+		return err
+	}
+	if aliases != nil {
+		names := stringSlice(aliases)
+		if len(names) > len(cols) {
+			return fmt.Errorf("relation \"%s\" specifies too many column names", name.Name)
+		}
+		for i := range names {
+			cols[i].Name = names[i]
+		}
+	}
+
+	tbl := &Table{Rel: name, Columns: cols, IsView: view}
+	if existing != nil {
+		tbl.Comment = existing.Comment
+		schema.Tables[idx] = tbl
+	} else {
+		schema.Tables = append(schema.Tables, tbl)
+	}
+	return nil
+}
@@ -41,3 +41,53 @@ func IsMathematicalOperator(s string) bool {
 	}
 	return true
 }
+
+func IsConcatOperator(s string) bool {
+	return s == "||"
+}
+
+// Operators, other than comparisons, which return a boolean, such as the
+// pattern matching, containment and existence operators
+func IsBooleanOperator(s string) bool {
+	switch s {
+	case "~~":
+	case "!~~":
+	case "~~*":
+	case "!~~*":
+	case "~*":
+	case "!~":
+	case "!~*":
+	case "@>":
+	case "<@":
+	case "&&":
+	case "?":
+	case "?|":
+	case "?&":
+	case "@@":
+	default:
+		return false
+	}
+	return true
+}
+
+// Operators which extract a value from a JSON document
+func IsJSONOperator(s string) bool {
+	switch s {
+	case "->":
+	case "#>":
+	default:
+		return IsJSONTextOperator(s)
+	}
+	return true
+}
+
+// Operators which extract a value from a JSON document as text
+func IsJSONTextOperator(s string) bool {
+	switch s {
+	case "->>":
+	case "#>>":
+	default:
+		return false
+	}
+	return true
+}
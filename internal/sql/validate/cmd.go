@@ -8,7 +8,17 @@ import (
 
 func Cmd(n ast.Node, name, cmd string) error {
 	// TODO: Convert cmd to an enum
-	if !(cmd == ":many" || cmd == ":one") {
+	if cmd == ":copyfrom" {
+		stmt, ok := n.(*ast.InsertStmt)
+		if !ok {
+			return fmt.Errorf("query %q specifies parameter %q without being an INSERT statement", name, cmd)
+		}
+		if err := copyFromInsertStmt(stmt); err != nil {
+			return fmt.Errorf("query %q specifies parameter %q: %w", name, cmd, err)
+		}
+		return nil
+	}
+	if !(cmd == ":many" || cmd == ":one" || cmd == ":batchmany" || cmd == ":batchone") {
 		return nil
 	}
 	var list *ast.List
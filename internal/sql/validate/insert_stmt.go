@@ -1,6 +1,8 @@
 package validate
 
 import (
+	"errors"
+
 	"github.com/kyleconroy/sqlc/internal/sql/ast"
 	"github.com/kyleconroy/sqlc/internal/sql/sqlerr"
 )
@@ -37,3 +39,40 @@ func InsertStmt(stmt *ast.InsertStmt) error {
 	}
 	return nil
 }
+
+// A :copyfrom query must insert a single row of parameters, each into a
+// named column, so that it can be repeated for every row copied
+func copyFromInsertStmt(stmt *ast.InsertStmt) error {
+	if stmt.WithClause != nil {
+		return errors.New("WITH is not supported")
+	}
+	if stmt.OnConflictClause != nil {
+		return errors.New("ON CONFLICT is not supported")
+	}
+	if stmt.ReturningList != nil && len(stmt.ReturningList.Items) > 0 {
+		return errors.New("RETURNING is not supported")
+	}
+	if stmt.Cols == nil || len(stmt.Cols.Items) == 0 {
+		return errors.New("the INSERT must list the columns it inserts into")
+	}
+	sel, ok := stmt.SelectStmt.(*ast.SelectStmt)
+	if !ok || sel.ValuesLists == nil || len(sel.ValuesLists.Items) != 1 {
+		return errors.New("the INSERT must have a single VALUES list")
+	}
+	sublist, ok := sel.ValuesLists.Items[0].(*ast.List)
+	if !ok {
+		return errors.New("the INSERT must have a single VALUES list")
+	}
+	seen := map[int]struct{}{}
+	for _, item := range sublist.Items {
+		ref, ok := item.(*ast.ParamRef)
+		if !ok {
+			return errors.New("every value must be a parameter")
+		}
+		if _, dup := seen[ref.Number]; dup {
+			return errors.New("every value must be a different parameter")
+		}
+		seen[ref.Number] = struct{}{}
+	}
+	return nil
+}